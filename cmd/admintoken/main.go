@@ -0,0 +1,84 @@
+// Command admintoken mints a bootstrap bearer token for the issuer API,
+// signed with a locally held RSA private key. It exists so a fresh
+// deployment has a way to obtain its first admin token before any other
+// token-issuing mechanism is wired up.
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+)
+
+func main() {
+	sub := flag.String("sub", "bootstrap-admin", "admin user id to embed in the 'sub' claim")
+	scopes := flag.String("scope", "identity:create claim:create claim:revoke state:publish admin", "space-separated scopes to grant")
+	did := flag.String("did", "", "optional DID restricting the token to a single issuer")
+	keyPath := flag.String("key", "", "path to the RSA private key (PEM) used to sign the token")
+	ttl := flag.Duration("ttl", 24*time.Hour, "token lifetime")
+	flag.Parse()
+
+	if *keyPath == "" {
+		log.Fatal("admintoken: -key is required")
+	}
+
+	keyPEM, err := os.ReadFile(*keyPath)
+	if err != nil {
+		log.Fatalf("admintoken: can not read signing key: %v", err)
+	}
+
+	privateKey, err := parseRSAPrivateKey(keyPEM)
+	if err != nil {
+		log.Fatalf("admintoken: can not parse signing key: %v", err)
+	}
+
+	now := time.Now()
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(*ttl)),
+		},
+		Subject: *sub,
+		Scope:   strings.TrimSpace(*scopes),
+		DID:     *did,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		log.Fatalf("admintoken: can not sign token: %v", err)
+	}
+
+	fmt.Println(token)
+}
+
+func parseRSAPrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("admintoken: key is not valid PEM")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("admintoken: key is not an RSA private key")
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	apilog "github.com/polygonid/sh-id-platform/internal/api/log"
+	"github.com/polygonid/sh-id-platform/internal/api/render"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/mgmt"
+)
+
+// batchMode controls how POST /v1/{identifier}/claims/batch handles a
+// partial failure within the batch.
+type batchMode string
+
+const (
+	batchModeAtomic     batchMode = "atomic"
+	batchModeBestEffort batchMode = "besteffort"
+)
+
+type batchItemResult struct {
+	Index int    `json:"index"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RegisterClaimBatchRoutes wires the batch claim issuance endpoint. It is
+// registered separately from the oapi-codegen surface because it predates
+// its addition to the API spec. Callers are expected to have already
+// mounted auth.Middleware on mux.
+func RegisterClaimBatchRoutes(mux *chi.Mux, srv *Server) {
+	mux.With(auth.RequireScope(auth.ScopeClaimCreate)).
+		Post("/v1/{identifier}/claims/batch", srv.createClaimBatch)
+}
+
+func (s *Server) createClaimBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	identifier := chi.URLParam(r, "identifier")
+
+	did, err := core.ParseDID(identifier)
+	if err != nil {
+		render.Error(w, r, errs.BadRequest(err.Error()))
+		return
+	}
+
+	if claims, ok := auth.FromContext(ctx); ok {
+		if err := auth.CheckDID(claims, did.String()); err != nil {
+			render.Error(w, r, err)
+			return
+		}
+	}
+
+	mode := batchMode(r.URL.Query().Get("mode"))
+	if mode == "" {
+		mode = batchModeAtomic
+	}
+	if mode != batchModeAtomic && mode != batchModeBestEffort {
+		render.Error(w, r, errs.BadRequest("mode must be 'atomic' or 'besteffort'"))
+		return
+	}
+
+	var items []ClaimInput
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		render.Error(w, r, errs.BadRequest("invalid request body: "+err.Error()))
+		return
+	}
+
+	if max := s.cfg.API.MaxBatchSize; max > 0 && len(items) > max {
+		render.Error(w, r, errs.PayloadTooLarge(
+			"batch exceeds the maximum allowed size"))
+		return
+	}
+
+	if len(items) == 0 {
+		render.JSON(w, http.StatusCreated, []batchItemResult{})
+		return
+	}
+
+	schema, err := s.schemaService.LoadSchema(ctx, items[0].CredentialSchema)
+	if err != nil {
+		render.Error(w, r, errs.Wrap(errs.BadRequest(err.Error()), "can not load schema"))
+		return
+	}
+
+	results := make([]batchItemResult, len(items))
+	claims := make([]*domain.Claim, 0, len(items))
+	issuanceRequests := make([]ports.IssuanceRequest, 0, len(items))
+	// claimIndices[i] is the original items index that claims[i] /
+	// issuanceRequests[i] was built from, since failed items are skipped
+	// rather than carried along at the same position.
+	claimIndices := make([]int, 0, len(items))
+	anyFailed := false
+
+	for i, in := range items {
+		if in.CredentialSchema != items[0].CredentialSchema {
+			anyFailed = true
+			results[i] = batchItemResult{Index: i, Status: "failed",
+				Error: "credentialSchema must match the first item's schema within a batch"}
+			continue
+		}
+
+		if err := s.mgmtService.ValidateClaimRequest(ctx, did.String(), mgmt.ClaimRequest{
+			Type:              in.Type,
+			Expiration:        in.Expiration,
+			CredentialSubject: in.CredentialSubject,
+		}); err != nil {
+			anyFailed = true
+			results[i] = batchItemResult{Index: i, Status: "failed", Error: err.Error()}
+			continue
+		}
+
+		claim, _, nonce, err := s.buildClaim(ctx, did, schema, in)
+		if err != nil {
+			anyFailed = true
+			results[i] = batchItemResult{Index: i, Status: "failed", Error: err.Error()}
+			continue
+		}
+		claims = append(claims, claim)
+		claimIndices = append(claimIndices, i)
+		issuanceRequests = append(issuanceRequests, ports.IssuanceRequest{
+			IssuerDID:             did.String(),
+			CredentialSchema:      in.CredentialSchema,
+			Type:                  in.Type,
+			CredentialSubject:     in.CredentialSubject,
+			Expiration:            in.Expiration,
+			Version:               in.Version,
+			SubjectPosition:       in.SubjectPosition,
+			MerklizedRootPosition: in.MerklizedRootPosition,
+			RevNonce:              uint64(nonce),
+		})
+		results[i] = batchItemResult{Index: i, Status: "pending"}
+	}
+
+	if mode == batchModeAtomic {
+		if anyFailed {
+			for i := range results {
+				if results[i].Status == "pending" {
+					results[i].Status = "aborted"
+					results[i].Error = "batch rolled back because another item failed"
+				}
+			}
+			render.JSON(w, http.StatusCreated, results)
+			return
+		}
+
+		if len(claims) > 0 {
+			if err := s.claimService.SaveBatch(ctx, claims); err != nil {
+				apilog.Error(ctx, r, "can not save claim batch", err)
+				for i := range results {
+					if results[i].Status == "pending" {
+						results[i].Status = "failed"
+						results[i].Error = "batch save failed: " + err.Error()
+					}
+				}
+				render.JSON(w, http.StatusCreated, results)
+				return
+			}
+
+			for i, idx := range claimIndices {
+				issuanceRequests[i].ClaimID = claims[i].ID
+				if err := s.claimService.RecordIssuance(ctx, issuanceRequests[i]); err != nil {
+					apilog.Error(ctx, r, "can not record claim issuance request", err)
+				}
+				results[idx] = batchItemResult{Index: idx, Status: "success", ID: claims[i].ID.String()}
+			}
+		}
+
+		render.JSON(w, http.StatusCreated, results)
+		return
+	}
+
+	// besteffort mode: persist every built claim independently, so that one
+	// item failing to save doesn't also fail the items that built and saved
+	// fine, unlike SaveBatch's single all-or-nothing transaction.
+	for i, idx := range claimIndices {
+		saved, err := s.claimService.Save(ctx, claims[i])
+		if err != nil {
+			apilog.Error(ctx, r, "can not save claim", err)
+			results[idx] = batchItemResult{Index: idx, Status: "failed", Error: "save failed: " + err.Error()}
+			continue
+		}
+
+		issuanceRequests[i].ClaimID = saved.ID
+		if err := s.claimService.RecordIssuance(ctx, issuanceRequests[i]); err != nil {
+			apilog.Error(ctx, r, "can not record claim issuance request", err)
+		}
+		results[idx] = batchItemResult{Index: idx, Status: "success", ID: saved.ID.String()}
+	}
+
+	render.JSON(w, http.StatusCreated, results)
+}
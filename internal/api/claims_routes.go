@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	"github.com/polygonid/sh-id-platform/internal/api/render"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// RegisterClaimRoutes wires claim lifecycle endpoints that are not part of
+// the oapi-codegen-generated surface, such as renewal. Callers are
+// expected to have already mounted auth.Middleware on mux; renewal also
+// requires the claim:create scope, same as issuing a fresh claim.
+func RegisterClaimRoutes(mux *chi.Mux, claimsService ports.ClaimsService) {
+	mux.With(auth.RequireScope(auth.ScopeClaimCreate)).
+		Post("/v1/{identifier}/claims/{id}/renew", renewClaim(claimsService))
+}
+
+func renewClaim(claimsService ports.ClaimsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identifier := chi.URLParam(r, "identifier")
+
+		did, err := core.ParseDID(identifier)
+		if err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		if claims, ok := auth.FromContext(r.Context()); ok {
+			if err := auth.CheckDID(claims, did.String()); err != nil {
+				render.Error(w, r, err)
+				return
+			}
+			if err := auth.CheckScope(claims, auth.ScopeClaimCreate); err != nil {
+				render.Error(w, r, err)
+				return
+			}
+		}
+
+		claimID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid claim id"))
+			return
+		}
+
+		revokePrevious, _ := strconv.ParseBool(r.URL.Query().Get("revokePrevious"))
+
+		renewed, revocationJobID, err := claimsService.Renew(r.Context(), did, claimID, ports.RenewOptions{RevokePrevious: revokePrevious})
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		resp := renewClaimResponse{ID: renewed.ID.String()}
+		if revocationJobID != nil {
+			jobID := revocationJobID.String()
+			resp.RevocationJobID = &jobID
+		}
+
+		render.JSON(w, http.StatusCreated, resp)
+	}
+}
+
+type renewClaimResponse struct {
+	ID              string  `json:"id"`
+	RevocationJobID *string `json:"revocationJobId,omitempty"`
+}
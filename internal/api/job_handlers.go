@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/jobs"
+)
+
+// RegisterJobHandlers wires the revoke_claim and publish_state handlers onto
+// pool, so that the jobs RevokeClaim/PublishState only enqueue are actually
+// carried out by the worker pool instead of sitting unroutable. Call once at
+// startup, after NewServer and before pool.Start.
+func RegisterJobHandlers(pool *jobs.WorkerPool, srv *Server) {
+	pool.Register(domain.JobTypeRevokeClaim, srv.handleRevokeClaimJob)
+	pool.Register(domain.JobTypePublishState, srv.handlePublishStateJob)
+}
+
+// handleRevokeClaimJob is the jobs.Handler for domain.JobTypeRevokeClaim. A
+// failure here, including repositories.ErrClaimDoesNotExist when the claim
+// was never valid to begin with, is surfaced to the caller asynchronously
+// through the job's Error field rather than synchronously from RevokeClaim.
+func (s *Server) handleRevokeClaimJob(ctx context.Context, parms json.RawMessage) error {
+	var p jobs.RevokeClaimParms
+	if err := json.Unmarshal(parms, &p); err != nil {
+		return errs.Wrap(errs.BadRequest(err.Error()), "can not unmarshal revoke claim job parms")
+	}
+	return s.claimService.Revoke(ctx, p.Identifier, p.Nonce, "revoked via the jobs subsystem")
+}
+
+// handlePublishStateJob is the jobs.Handler for domain.JobTypePublishState.
+func (s *Server) handlePublishStateJob(ctx context.Context, parms json.RawMessage) error {
+	var p jobs.PublishStateParms
+	if err := json.Unmarshal(parms, &p); err != nil {
+		return errs.Wrap(errs.BadRequest(err.Error()), "can not unmarshal publish state job parms")
+	}
+	return s.identityService.PublishState(ctx, p.Identifier)
+}
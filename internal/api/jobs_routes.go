@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	"github.com/polygonid/sh-id-platform/internal/api/render"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// RegisterJobRoutes wires the job polling and admin endpoints onto mux.
+// These are plain chi routes rather than StrictServerInterface methods
+// because they are polled by operators/scripts, not part of the public
+// credential issuance surface. Callers are expected to have already
+// mounted auth.Middleware on mux; these endpoints additionally require
+// the admin scope, same as the rest of the operator-facing surface.
+func RegisterJobRoutes(mux *chi.Mux, jobsService ports.JobsService) {
+	mux.Route("/jobs", func(r chi.Router) {
+		r.Use(auth.RequireScope(auth.ScopeAdmin))
+		r.Get("/{id}", getJob(jobsService))
+		r.Get("/", getJobs(jobsService))
+		r.Post("/{id}/retry", retryJob(jobsService))
+	})
+}
+
+func getJob(svc ports.JobsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid job id"))
+			return
+		}
+
+		job, err := svc.GetByID(r.Context(), id)
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, job)
+	}
+}
+
+func getJobs(svc ports.JobsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := ports.JobsFilter{}
+		if t := r.URL.Query().Get("type"); t != "" {
+			jobType := domain.JobType(t)
+			filter.Type = &jobType
+		}
+		if s := r.URL.Query().Get("status"); s != "" {
+			status := domain.JobStatus(s)
+			filter.Status = &status
+		}
+
+		jobList, err := svc.GetAll(r.Context(), filter)
+		if err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not list jobs"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, jobList)
+	}
+}
+
+func retryJob(svc ports.JobsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid job id"))
+			return
+		}
+
+		job, err := svc.Retry(r.Context(), id)
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, job)
+	}
+}
@@ -0,0 +1,30 @@
+// Package log adds request-scoped structured fields (method, path,
+// request id) on top of the generic internal/log package, so transport
+// code logs consistently without repeating that boilerplate at every call
+// site.
+package log
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	internallog "github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// Fields returns the request-scoped fields to attach to any log entry
+// produced while handling r.
+func Fields(r *http.Request) []interface{} {
+	return []interface{}{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"request-id", middleware.GetReqID(r.Context()),
+	}
+}
+
+// Error logs err alongside the request-scoped fields for r.
+func Error(ctx context.Context, r *http.Request, msg string, err error) {
+	args := append([]interface{}{"err", err}, Fields(r)...)
+	internallog.Error(ctx, msg, args...)
+}
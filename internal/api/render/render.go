@@ -0,0 +1,57 @@
+// Package render centralizes how the API turns values and errors into HTTP
+// responses, so handlers stop hand-rolling a JSON-response-plus-log-call
+// for every failure branch.
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apilog "github.com/polygonid/sh-id-platform/internal/api/log"
+)
+
+// StatusCoder is implemented by errors that know which HTTP status they
+// should be rendered with.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// RenderableError is a StatusCoder that is also a regular error, letting it
+// be both returned from service methods and written straight to the wire.
+type RenderableError interface {
+	error
+	StatusCoder
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+// JSON writes v as a JSON body with the given status code.
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Error logs err with request-scoped fields and writes it to w, using err's
+// own status code when it implements StatusCoder and 500 otherwise. It is
+// meant to be registered once as the strict server's
+// ResponseErrorHandlerFunc, so handlers only ever need to `return nil, err`.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if sc, ok := err.(StatusCoder); ok {
+		status = sc.StatusCode()
+	}
+
+	apilog.Error(r.Context(), r, "request failed", err)
+
+	msg := err.Error()
+	if status >= http.StatusInternalServerError {
+		// err.Error() on a 5xx can carry wrapped internal detail (a DB
+		// error, a stack-adjacent message) that the client has no business
+		// seeing; the full error is already logged above via apilog.Error.
+		msg = "internal server error"
+	}
+	JSON(w, status, response{Message: msg})
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	mgmtapi "github.com/polygonid/sh-id-platform/internal/mgmt/api"
+	replicationapi "github.com/polygonid/sh-id-platform/internal/replication/api"
+)
+
+// RegisterRoutes mounts every HTTP route this service exposes outside the
+// oapi-codegen StrictServerInterface surface (CreateIdentity, CreateClaim,
+// RevokeClaim, PublishState, GetClaim, GetRevocationStatus), except the
+// static documentation routes that RegisterStatic mounts, and wires
+// bearer-token authentication in front of all of it.
+//
+// auth.Middleware is mounted once here as mux-level middleware, so it also
+// covers the StrictServerInterface routes once they are mounted on the
+// same mux; those handlers enforce their own required scope via
+// auth.CheckScope since they can't be wrapped with auth.RequireScope from
+// outside the generated ServerInterfaceWrapper. Every route registered
+// here instead goes through auth.RequireScope directly.
+func RegisterRoutes(mux *chi.Mux, srv *Server) {
+	mux.Use(auth.Middleware(srv.cfg.APIAuth))
+
+	RegisterJobRoutes(mux, srv.jobsService)
+	RegisterClaimRoutes(mux, srv.claimService)
+	RegisterClaimBatchRoutes(mux, srv)
+	mgmtapi.RegisterRoutes(mux, srv.mgmtService)
+	replicationapi.RegisterRoutes(mux, srv.replicationService)
+}
@@ -3,7 +3,6 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,31 +11,40 @@ import (
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-schema-processor/processor"
 
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
 	"github.com/polygonid/sh-id-platform/internal/common"
 	"github.com/polygonid/sh-id-platform/internal/config"
 	"github.com/polygonid/sh-id-platform/internal/core/domain"
 	"github.com/polygonid/sh-id-platform/internal/core/ports"
-	"github.com/polygonid/sh-id-platform/internal/log"
-	"github.com/polygonid/sh-id-platform/internal/repositories"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/jobs"
+	"github.com/polygonid/sh-id-platform/internal/mgmt"
+	"github.com/polygonid/sh-id-platform/internal/replication"
 	"github.com/polygonid/sh-id-platform/pkg/rand"
 )
 
 // Server implements StrictServerInterface and holds the implementation of all API controllers
 // This is the glue to the API autogenerated code
 type Server struct {
-	cfg             *config.Configuration
-	identityService ports.IndentityService
-	claimService    ports.ClaimsService
-	schemaService   ports.SchemaService
+	cfg                *config.Configuration
+	identityService    ports.IndentityService
+	claimService       ports.ClaimsService
+	schemaService      ports.SchemaService
+	jobsService        ports.JobsService
+	mgmtService        *mgmt.Service
+	replicationService *replication.Service
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IndentityService, claimsService ports.ClaimsService, schemaService ports.SchemaService) *Server {
+func NewServer(cfg *config.Configuration, identityService ports.IndentityService, claimsService ports.ClaimsService, schemaService ports.SchemaService, jobsService ports.JobsService, mgmtService *mgmt.Service, replicationService *replication.Service) *Server {
 	return &Server{
-		cfg:             cfg,
-		identityService: identityService,
-		claimService:    claimsService,
-		schemaService:   schemaService,
+		cfg:                cfg,
+		identityService:    identityService,
+		claimService:       claimsService,
+		schemaService:      schemaService,
+		jobsService:        jobsService,
+		mgmtService:        mgmtService,
+		replicationService: replicationService,
 	}
 }
 
@@ -85,6 +93,12 @@ func writeFile(path string, w http.ResponseWriter) {
 
 // CreateIdentity is created identity controller
 func (s *Server) CreateIdentity(ctx context.Context, request CreateIdentityRequestObject) (CreateIdentityResponseObject, error) {
+	if claims, ok := auth.FromContext(ctx); ok {
+		if err := auth.CheckScope(claims, auth.ScopeIdentityCreate); err != nil {
+			return nil, err
+		}
+	}
+
 	identity, err := s.identityService.Create(ctx, fmt.Sprintf("%s:%d", s.cfg.ServerUrl, s.cfg.ServerPort))
 	if err != nil {
 		return nil, err
@@ -109,43 +123,124 @@ func (s *Server) CreateIdentity(ctx context.Context, request CreateIdentityReque
 	}, nil
 }
 
+// ClaimInput is the body shared by single and batch claim issuance.
+type ClaimInput struct {
+	CredentialSchema      string                 `json:"credentialSchema"`
+	CredentialSubject     map[string]interface{} `json:"credentialSubject"`
+	Expiration            *int64                 `json:"expiration,omitempty"`
+	Type                  string                 `json:"type"`
+	Version               uint32                 `json:"version,omitempty"`
+	SubjectPosition       string                 `json:"subjectPosition,omitempty"`
+	MerklizedRootPosition string                 `json:"merklizedRootPosition,omitempty"`
+}
+
 // CreateClaim is claim creation controller
 func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObject) (CreateClaimResponseObject, error) {
 	if request.Identifier == "" {
-		return CreateClaim400JSONResponse{N400JSONResponse{Message: "Invalid request identifier"}}, nil
+		return nil, errs.BadRequest("Invalid request identifier")
 	}
 
 	did, err := core.ParseDID(request.Identifier)
 	if err != nil {
-		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		return nil, errs.BadRequest(err.Error())
+	}
+
+	if claims, ok := auth.FromContext(ctx); ok {
+		if err := auth.CheckDID(claims, did.String()); err != nil {
+			return nil, err
+		}
+		if err := auth.CheckScope(claims, auth.ScopeClaimCreate); err != nil {
+			return nil, err
+		}
+	}
+
+	in := ClaimInput{
+		CredentialSchema:      request.Body.CredentialSchema,
+		CredentialSubject:     request.Body.CredentialSubject,
+		Expiration:            request.Body.Expiration,
+		Type:                  request.Body.Type,
+		Version:               request.Body.Version,
+		SubjectPosition:       request.Body.SubjectPosition,
+		MerklizedRootPosition: request.Body.MerklizedRootPosition,
+	}
+
+	if err := s.mgmtService.ValidateClaimRequest(ctx, did.String(), mgmt.ClaimRequest{
+		Type:              in.Type,
+		Expiration:        in.Expiration,
+		CredentialSubject: in.CredentialSubject,
+	}); err != nil {
+		return nil, err
+	}
+
+	schema, err := s.schemaService.LoadSchema(ctx, in.CredentialSchema)
+	if err != nil {
+		return nil, errs.Wrap(errs.BadRequest(err.Error()), "can not load schema")
+	}
+
+	claim, credentialType, nonce, err := s.buildClaim(ctx, did, schema, in)
+	if err != nil {
+		return nil, err
 	}
 
-	schema, err := s.schemaService.LoadSchema(ctx, request.Body.CredentialSchema)
+	claimResp, err := s.claimService.Save(ctx, claim)
 	if err != nil {
-		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		return nil, errs.Wrap(errs.Internal(err), "can not save the claim")
+	}
+
+	if err := s.claimService.RecordIssuance(ctx, ports.IssuanceRequest{
+		ClaimID:               claimResp.ID,
+		IssuerDID:             did.String(),
+		CredentialSchema:      in.CredentialSchema,
+		Type:                  in.Type,
+		CredentialSubject:     in.CredentialSubject,
+		Expiration:            in.Expiration,
+		Version:               in.Version,
+		SubjectPosition:       in.SubjectPosition,
+		MerklizedRootPosition: in.MerklizedRootPosition,
+		RevNonce:              uint64(nonce),
+	}); err != nil {
+		return nil, errs.Wrap(errs.Internal(err), "can not record the claim issuance request")
 	}
 
-	claimReq := ports.NewClaimRequest(schema, did, request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition)
+	vc, err := json.Marshal(claim.Data)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal(err), "can not marshal the credential for replication")
+	}
+	credentialStatus, err := json.Marshal(claim.CredentialStatus)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal(err), "can not marshal the credential status for replication")
+	}
+	s.replicationService.Trigger(ctx, did.String(), replication.TriggerOnIssue, claimResp.ID.String(), credentialType, vc, credentialStatus)
+
+	return CreateClaim201JSONResponse{Id: claimResp.ID.String()}, nil
+}
+
+// buildClaim signs and assembles a single domain.Claim from in, against an
+// already-loaded schema, without persisting it. It is shared by CreateClaim
+// and the batch issuance endpoint, which load the schema once for the
+// whole batch. The returned credential type is the fully-qualified
+// jsonLdContext#type string, needed by CreateClaim to trigger replication;
+// the returned nonce is the revocation nonce the claim was issued with,
+// needed to record its IssuanceRequest.
+func (s *Server) buildClaim(ctx context.Context, did *core.DID, schema *processor.Schema, in ClaimInput) (*domain.Claim, string, int64, error) {
+	claimReq := ports.NewClaimRequest(schema, did, in.CredentialSchema, in.CredentialSubject, in.Expiration, in.Type, in.Version, in.SubjectPosition, in.MerklizedRootPosition)
 
 	nonce, err := rand.Int64()
 	if err != nil {
-		log.Error(ctx, "Can not create a nonce", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not create a nonce")
 	}
 
 	vc, err := s.claimService.CreateVC(ctx, claimReq, nonce)
 	if err != nil {
-		log.Error(ctx, "Can not create a claim", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not create a claim")
 	}
 
 	jsonLdContext, ok := schema.Metadata.Uris["jsonLdContext"].(string)
 	if !ok {
-		log.Warn(ctx, "invalid jsonLdContext")
-		return CreateClaim400JSONResponse{N400JSONResponse{Message: "invalid jsonLdContext"}}, nil
+		return nil, "", 0, errs.BadRequest("invalid jsonLdContext")
 	}
 
-	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, request.Body.Type)
+	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, in.Type)
 	mtRootPostion := common.DefineMerklizedRootPosition(schema.Metadata, claimReq.MerklizedRootPosition)
 
 	coreClaim, err := s.schemaService.Process(ctx, claimReq.CredentialSchema, credentialType, vc, &processor.CoreClaimOptions{
@@ -156,28 +251,23 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 		Updatable:             false,
 	})
 	if err != nil {
-		log.Error(ctx, "Can not process the schema", err)
-		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.BadRequest(err.Error()), "can not process the schema")
 	}
 
 	claim, err := domain.FromClaimer(coreClaim, claimReq.CredentialSchema, credentialType)
 	if err != nil {
-		log.Error(ctx, "Can not obtain the claim from claimer", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not obtain the claim from claimer")
 	}
 
 	authClaim, err := s.claimService.GetAuthClaim(ctx, did)
 	if err != nil {
-		log.Error(ctx, "Can not retrieve the auth claim", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
-
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not retrieve the auth claim")
 	}
 
 	proof, err := s.identityService.SignClaimEntry(ctx, authClaim,
 		coreClaim)
 	if err != nil {
-		log.Error(ctx, "Can not sign claim entry", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not sign claim entry")
 	}
 
 	issuerDIDString := did.String()
@@ -188,49 +278,49 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 
 	jsonSignatureProof, err := json.Marshal(proof)
 	if err != nil {
-		log.Error(ctx, "Can not encode the json signature proof", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
-	}
-	err = claim.SignatureProof.Set(jsonSignatureProof)
-	if err != nil {
-		log.Error(ctx, "Can not set the json signature proof", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not encode the json signature proof")
 	}
-
-	err = claim.Data.Set(vc)
-	if err != nil {
-		log.Error(ctx, "Can not set the credential", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	if err := claim.SignatureProof.Set(jsonSignatureProof); err != nil {
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not set the json signature proof")
 	}
 
-	err = claim.CredentialStatus.Set(vc.CredentialStatus)
-	if err != nil {
-		log.Error(ctx, "Can not set the credential status", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	if err := claim.Data.Set(vc); err != nil {
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not set the credential")
 	}
 
-	claimResp, err := s.claimService.Save(ctx, claim)
-	if err != nil {
-		log.Error(ctx, "Can not save the claim", err)
-		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	if err := claim.CredentialStatus.Set(vc.CredentialStatus); err != nil {
+		return nil, "", 0, errs.Wrap(errs.Internal(err), "can not set the credential status")
 	}
 
-	return CreateClaim201JSONResponse{Id: claimResp.ID.String()}, nil
+	return claim, credentialType, nonce, nil
 }
 
-// RevokeClaim is the revocation claim controller
+// RevokeClaim is the revocation claim controller. Revocation itself is
+// handled asynchronously by the jobs subsystem's revoke_claim handler, so
+// this only enqueues the work: it does not validate that the claim exists,
+// and a revocation of a nonexistent claim surfaces as a failed job (its
+// Error field) rather than a synchronous 404 here.
 func (s *Server) RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error) {
-	if err := s.claimService.Revoke(ctx, request.Identifier, uint64(request.Nonce), ""); err != nil {
-		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
-			return RevokeClaim404JSONResponse{N404JSONResponse{
-				Message: "the claim does not exist",
-			}}, nil
+	if claims, ok := auth.FromContext(ctx); ok {
+		if err := auth.CheckDID(claims, request.Identifier); err != nil {
+			return nil, err
+		}
+		if err := auth.CheckScope(claims, auth.ScopeClaimRevoke); err != nil {
+			return nil, err
 		}
+	}
 
-		return RevokeClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	job, err := s.jobsService.Enqueue(ctx, domain.JobTypeRevokeClaim, jobs.RevokeClaimParms{
+		Identifier: request.Identifier,
+		Nonce:      uint64(request.Nonce),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err, "can not enqueue revoke claim job")
 	}
+
 	return RevokeClaim202JSONResponse{
 		Status: "pending",
+		JobId:  job.ID.String(),
 	}, nil
 }
 
@@ -239,9 +329,27 @@ func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationS
 	return nil, nil
 }
 
-// PublishState is the controller to publish the state on-chain
+// PublishState is the controller to publish the state on-chain. Publishing
+// is long-running and retry-prone, so it is handed off to the jobs
+// subsystem and the caller polls GET /jobs/{id} for its outcome.
 func (s *Server) PublishState(ctx context.Context, request PublishStateRequestObject) (PublishStateResponseObject, error) {
-	return nil, nil
+	if claims, ok := auth.FromContext(ctx); ok {
+		if err := auth.CheckScope(claims, auth.ScopeStatePublish); err != nil {
+			return nil, err
+		}
+	}
+
+	job, err := s.jobsService.Enqueue(ctx, domain.JobTypePublishState, jobs.PublishStateParms{
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		return nil, errs.Wrap(err, "can not enqueue publish state job")
+	}
+
+	return PublishState202JSONResponse{
+		JobId:  job.ID.String(),
+		Status: string(job.Status),
+	}, nil
 }
 
 func (s *Server) GetClaim(ctx context.Context, request GetClaimRequestObject) (GetClaimResponseObject, error) {
@@ -0,0 +1,68 @@
+package claims
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// ErrIssuanceRequestDoesNotExist is returned when no issuance request has
+// been recorded for a claim, e.g. because it predates this subsystem.
+var ErrIssuanceRequestDoesNotExist = errs.NotFound("no issuance request recorded for this claim")
+
+// pgStore is the postgres-backed implementation of Store.
+type pgStore struct{}
+
+// NewPostgresStore is a Store constructor.
+func NewPostgresStore() Store {
+	return &pgStore{}
+}
+
+func (s *pgStore) SaveIssuanceRequest(ctx context.Context, req ports.IssuanceRequest) error {
+	credentialSubject, err := json.Marshal(req.CredentialSubject)
+	if err != nil {
+		return err
+	}
+
+	conn := db.FromContext(ctx)
+	_, err = conn.Exec(ctx,
+		`INSERT INTO claim_issuance_requests
+		   (claim_id, issuer_did, credential_schema, type, credential_subject, expiration,
+		    version, subject_position, merklized_root_position, rev_nonce)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (claim_id) DO UPDATE SET
+		   credential_schema = $3, type = $4, credential_subject = $5, expiration = $6,
+		   version = $7, subject_position = $8, merklized_root_position = $9, rev_nonce = $10`,
+		req.ClaimID, req.IssuerDID, req.CredentialSchema, req.Type, credentialSubject, req.Expiration,
+		req.Version, req.SubjectPosition, req.MerklizedRootPosition, int64(req.RevNonce))
+	return err
+}
+
+func (s *pgStore) GetIssuanceRequest(ctx context.Context, issuerDID string, claimID uuid.UUID) (*ports.IssuanceRequest, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT claim_id, issuer_did, credential_schema, type, credential_subject, expiration,
+		        version, subject_position, merklized_root_position, rev_nonce
+		 FROM claim_issuance_requests WHERE claim_id = $1 AND issuer_did = $2`, claimID, issuerDID)
+
+	var credentialSubject []byte
+	var revNonce int64
+	req := ports.IssuanceRequest{}
+	if err := row.Scan(&req.ClaimID, &req.IssuerDID, &req.CredentialSchema, &req.Type, &credentialSubject,
+		&req.Expiration, &req.Version, &req.SubjectPosition, &req.MerklizedRootPosition, &revNonce); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrIssuanceRequestDoesNotExist
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(credentialSubject, &req.CredentialSubject); err != nil {
+		return nil, err
+	}
+	req.RevNonce = uint64(revNonce)
+	return &req, nil
+}
@@ -0,0 +1,246 @@
+package claims
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/processor"
+
+	"github.com/polygonid/sh-id-platform/internal/common"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/jobs"
+	"github.com/polygonid/sh-id-platform/internal/mgmt"
+	"github.com/polygonid/sh-id-platform/pkg/rand"
+)
+
+// Service decorates a base ports.ClaimsService, which already implements
+// CreateVC, GetAuthClaim, Save, Revoke and GetRevocationSource, adding the
+// three methods that need a persistence layer and collaborators of their
+// own: SaveBatch, Renew and RecordIssuance.
+type Service struct {
+	ports.ClaimsService
+	store           Store
+	schemaService   ports.SchemaService
+	identityService ports.IndentityService
+	mgmtService     *mgmt.Service
+	jobsService     ports.JobsService
+}
+
+// NewService is a Service constructor. base is the concrete
+// ports.ClaimsService whose CreateVC/GetAuthClaim/Save/Revoke/
+// GetRevocationSource this decorator reuses as-is.
+func NewService(base ports.ClaimsService, store Store, schemaService ports.SchemaService, identityService ports.IndentityService, mgmtService *mgmt.Service, jobsService ports.JobsService) *Service {
+	return &Service{
+		ClaimsService:   base,
+		store:           store,
+		schemaService:   schemaService,
+		identityService: identityService,
+		mgmtService:     mgmtService,
+		jobsService:     jobsService,
+	}
+}
+
+// RecordIssuance stores req so the claim it describes can later be renewed.
+func (s *Service) RecordIssuance(ctx context.Context, req ports.IssuanceRequest) error {
+	return s.store.SaveIssuanceRequest(ctx, req)
+}
+
+// SaveBatch persists claims in a single DB transaction, by re-running the
+// base service's own Save for each one inside a db.ExecTx: every repository
+// call made against the context db.ExecTx hands to fn shares the same
+// transaction, so either every claim is saved or none are.
+func (s *Service) SaveBatch(ctx context.Context, claimList []*domain.Claim) error {
+	return db.ExecTx(ctx, func(txCtx context.Context) error {
+		for _, claim := range claimList {
+			if _, err := s.ClaimsService.Save(txCtx, claim); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Renew re-issues the claim identified by claimID under did, copying its
+// schema, type, credential subject, version and subject position from the
+// IssuanceRequest recorded when it (or its most recent renewal) was
+// created, bumping the version and generating a fresh revocation nonce. A
+// claim past its expiration can only be renewed if the issuer's issuance
+// policy allows it and the grace period configured on that policy has not
+// elapsed. When opts.RevokePrevious is set, the original claim is revoked
+// through the jobs subsystem and the resulting job's id is returned
+// alongside the renewed claim.
+func (s *Service) Renew(ctx context.Context, did *core.DID, claimID uuid.UUID, opts ports.RenewOptions) (*domain.Claim, *uuid.UUID, error) {
+	issuerDID := did.String()
+
+	original, err := s.store.GetIssuanceRequest(ctx, issuerDID, claimID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkRenewalWindow(ctx, issuerDID, original); err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := s.schemaService.LoadSchema(ctx, original.CredentialSchema)
+	if err != nil {
+		return nil, nil, errs.Wrap(errs.BadRequest(err.Error()), "can not load schema")
+	}
+
+	claim, nonce, err := s.buildRenewedClaim(ctx, did, schema, *original, original.Version+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	renewed, err := s.ClaimsService.Save(ctx, claim)
+	if err != nil {
+		return nil, nil, errs.Wrap(errs.Internal(err), "can not save the renewed claim")
+	}
+
+	if err := s.store.SaveIssuanceRequest(ctx, ports.IssuanceRequest{
+		ClaimID:               renewed.ID,
+		IssuerDID:             issuerDID,
+		CredentialSchema:      original.CredentialSchema,
+		Type:                  original.Type,
+		CredentialSubject:     original.CredentialSubject,
+		Expiration:            original.Expiration,
+		Version:               original.Version + 1,
+		SubjectPosition:       original.SubjectPosition,
+		MerklizedRootPosition: original.MerklizedRootPosition,
+		RevNonce:              uint64(nonce),
+	}); err != nil {
+		return nil, nil, errs.Wrap(errs.Internal(err), "can not record the renewed claim's issuance request")
+	}
+
+	var revocationJobID *uuid.UUID
+	if opts.RevokePrevious {
+		job, err := s.jobsService.Enqueue(ctx, domain.JobTypeRevokeClaim, jobs.RevokeClaimParms{
+			Identifier: issuerDID,
+			Nonce:      original.RevNonce,
+		})
+		if err != nil {
+			return nil, nil, errs.Wrap(errs.Internal(err), "can not enqueue revocation of the previous claim")
+		}
+		revocationJobID = &job.ID
+	}
+
+	return renewed, revocationJobID, nil
+}
+
+// checkRenewalWindow rejects renewing a claim that has expired, unless the
+// issuer's issuance policy allows renewal after expiry and the request
+// falls within its configured grace period. A claim with no expiration, or
+// one that has not expired yet, is always renewable; an expired claim whose
+// issuer has no configured policy is not.
+func (s *Service) checkRenewalWindow(ctx context.Context, issuerDID string, original *ports.IssuanceRequest) error {
+	if original.Expiration == nil {
+		return nil
+	}
+
+	expiresAt := time.Unix(*original.Expiration, 0)
+	if time.Now().Before(expiresAt) {
+		return nil
+	}
+
+	policy, err := s.mgmtService.IssuancePolicy(ctx, issuerDID)
+	if err != nil {
+		if errors.Is(err, mgmt.ErrIssuancePolicyDoesNotExist) {
+			return errs.Forbidden("the claim has expired and the issuer has no renewal policy configured")
+		}
+		return errs.Wrap(errs.Internal(err), "can not load issuance policy")
+	}
+
+	if !policy.AllowRenewAfterExpiry {
+		return errs.Forbidden("the claim has expired and the issuance policy does not allow renewal after expiry")
+	}
+	if time.Now().After(expiresAt.Add(policy.RenewAfterExpiryGrace)) {
+		return errs.Forbidden("the claim's renew-after-expiry grace period has elapsed")
+	}
+	return nil
+}
+
+// buildRenewedClaim mirrors api.Server.buildClaim's sign-and-assemble
+// sequence for the subset of ClaimInput that a renewal carries over from
+// the original issuance request, at newVersion. It is duplicated rather
+// than shared because buildClaim is a private method of a different
+// package's type; both build against the same schemaService/
+// identityService/ClaimsService collaborators.
+func (s *Service) buildRenewedClaim(ctx context.Context, did *core.DID, schema *processor.Schema, original ports.IssuanceRequest, newVersion uint32) (*domain.Claim, int64, error) {
+	claimReq := ports.NewClaimRequest(schema, did, original.CredentialSchema, original.CredentialSubject,
+		original.Expiration, original.Type, newVersion, original.SubjectPosition, original.MerklizedRootPosition)
+
+	nonce, err := rand.Int64()
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not create a nonce")
+	}
+
+	vc, err := s.CreateVC(ctx, claimReq, nonce)
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not create a claim")
+	}
+
+	jsonLdContext, ok := schema.Metadata.Uris["jsonLdContext"].(string)
+	if !ok {
+		return nil, 0, errs.BadRequest("invalid jsonLdContext")
+	}
+
+	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, original.Type)
+	mtRootPosition := common.DefineMerklizedRootPosition(schema.Metadata, claimReq.MerklizedRootPosition)
+
+	coreClaim, err := s.schemaService.Process(ctx, claimReq.CredentialSchema, credentialType, vc, &processor.CoreClaimOptions{
+		RevNonce:              nonce,
+		MerklizedRootPosition: mtRootPosition,
+		Version:               claimReq.Version,
+		SubjectPosition:       claimReq.SubjectPos,
+		Updatable:             false,
+	})
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.BadRequest(err.Error()), "can not process the schema")
+	}
+
+	claim, err := domain.FromClaimer(coreClaim, claimReq.CredentialSchema, credentialType)
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not obtain the claim from claimer")
+	}
+
+	authClaim, err := s.GetAuthClaim(ctx, did)
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not retrieve the auth claim")
+	}
+
+	proof, err := s.identityService.SignClaimEntry(ctx, authClaim, coreClaim)
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not sign claim entry")
+	}
+
+	issuerDIDString := did.String()
+	claim.Identifier = &issuerDIDString
+	claim.Issuer = issuerDIDString
+
+	proof.IssuerData.CredentialStatus = s.GetRevocationSource(issuerDIDString, uint64(authClaim.RevNonce))
+
+	jsonSignatureProof, err := json.Marshal(proof)
+	if err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not encode the json signature proof")
+	}
+	if err := claim.SignatureProof.Set(jsonSignatureProof); err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not set the json signature proof")
+	}
+
+	if err := claim.Data.Set(vc); err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not set the credential")
+	}
+
+	if err := claim.CredentialStatus.Set(vc.CredentialStatus); err != nil {
+		return nil, 0, errs.Wrap(errs.Internal(err), "can not set the credential status")
+	}
+
+	return claim, nonce, nil
+}
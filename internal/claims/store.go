@@ -0,0 +1,23 @@
+// Package claims decorates ports.ClaimsService with the pieces that need a
+// persistence layer of their own: batching multiple already-built claims
+// into one transaction, recording/looking up the parameters a claim was
+// issued with, and renewing a claim (rebuilding and re-signing it) from
+// those recorded parameters.
+package claims
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// Store is the DB-backed persistence layer for issuance requests. SaveBatch
+// has no dedicated store of its own: it re-saves each claim through the
+// base ports.ClaimsService inside a single db.ExecTx, since the claims
+// table itself belongs to that service's (external) implementation.
+type Store interface {
+	SaveIssuanceRequest(ctx context.Context, req ports.IssuanceRequest) error
+	GetIssuanceRequest(ctx context.Context, issuerDID string, claimID uuid.UUID) (*ports.IssuanceRequest, error)
+}
@@ -0,0 +1,36 @@
+// Package config holds the server's runtime configuration, normally
+// loaded from environment variables / a config file at startup.
+package config
+
+// Configuration is the root configuration object threaded through the
+// application's constructors.
+type Configuration struct {
+	ServerUrl  string
+	ServerPort int
+
+	APIAuth APIAuth
+	API     API
+}
+
+// API holds tunables for the credential issuance HTTP surface.
+type API struct {
+	// MaxBatchSize caps how many items POST /v1/{identifier}/claims/batch
+	// accepts in a single request. Zero means no limit.
+	MaxBatchSize int
+}
+
+// APIAuth configures bearer-token authentication for the credential
+// issuance API.
+type APIAuth struct {
+	// Disabled turns authentication off entirely. Only meant for local
+	// development; never set in a deployed environment.
+	Disabled bool
+
+	// JWKSURL is the JWKS endpoint used to validate inbound bearer tokens.
+	// Mutually exclusive with StaticKeys below.
+	JWKSURL string
+
+	// StaticKeys lets a deployment pin a fixed set of signing keys (PEM
+	// encoded) instead of fetching a JWKS, useful for air-gapped setups.
+	StaticKeys []string
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobType identifies the kind of work a Job performs.
+type JobType string
+
+const (
+	// JobTypePublishState publishes an identity state on-chain.
+	JobTypePublishState JobType = "publish_state"
+	// JobTypeRevokeClaim processes a claim revocation.
+	JobTypeRevokeClaim JobType = "revoke_claim"
+	// JobTypeReplicate pushes an issued or revoked credential (or a
+	// published state) to a replication policy's targets.
+	JobTypeReplicate JobType = "replicate"
+)
+
+// JobStatus is the current lifecycle state of a Job.
+type JobStatus string
+
+const (
+	// JobStatusPending means the job has been enqueued but not picked up yet.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning means a worker is currently executing the job.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusRetrying means the job failed and is waiting for its next backoff attempt.
+	JobStatusRetrying JobStatus = "retrying"
+	// JobStatusSucceeded means the job completed without error.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed means the job exhausted its retries without succeeding,
+	// or no handler is registered for its type.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// Job is a unit of asynchronous work tracked in the jobs table.
+type Job struct {
+	ID     uuid.UUID
+	Type   JobType
+	Status JobStatus
+	Parms  json.RawMessage
+	Error  *string
+	// Attempts counts how many times this job has been run and failed.
+	Attempts int
+	// NextAttemptAt is when this job next becomes eligible for pickup. For
+	// a fresh pending job it is the creation time; for a retrying job it is
+	// set to the end of its backoff delay, so the claiming query's
+	// next_attempt_at <= now() filter is what actually enforces backoff.
+	NextAttemptAt time.Time
+	CreationTime  time.Time
+	UpdateTime    time.Time
+}
+
+// NewJob creates a Job in the pending state for the given type and parameters.
+func NewJob(jobType JobType, parms json.RawMessage) *Job {
+	now := time.Now()
+	return &Job{
+		ID:            uuid.New(),
+		Type:          jobType,
+		Status:        JobStatusPending,
+		Parms:         parms,
+		NextAttemptAt: now,
+		CreationTime:  now,
+		UpdateTime:    now,
+	}
+}
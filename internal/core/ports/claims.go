@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// RenewOptions controls how Renew re-issues a credential.
+type RenewOptions struct {
+	// RevokePrevious, when true, revokes the original credential once the
+	// renewed one has been saved successfully.
+	RevokePrevious bool
+}
+
+// IssuanceRequest records the parameters a claim was issued with: its
+// schema, type, credential subject, version and encoding options. Saved
+// alongside every issued claim via RecordIssuance so Renew can rebuild it
+// later without the caller resupplying them.
+type IssuanceRequest struct {
+	ClaimID               uuid.UUID
+	IssuerDID             string
+	CredentialSchema      string
+	Type                  string
+	CredentialSubject     map[string]interface{}
+	Expiration            *int64
+	Version               uint32
+	SubjectPosition       string
+	MerklizedRootPosition string
+	// RevNonce is the revocation nonce the claim was issued with, needed to
+	// revoke it later (e.g. when Renew is called with RevokePrevious).
+	RevNonce uint64
+}
+
+// ClaimsService is the application service behind claim issuance,
+// persistence and lifecycle management.
+type ClaimsService interface {
+	CreateVC(ctx context.Context, claimReq *ClaimRequest, nonce int64) (interface{}, error)
+	GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim, error)
+	Save(ctx context.Context, claim *domain.Claim) (*domain.Claim, error)
+	Revoke(ctx context.Context, identifier string, nonce uint64, description string) error
+	GetRevocationSource(issuerDID string, nonce uint64) interface{}
+	// Renew re-issues the claim identified by claimID under did, copying its
+	// schema, type, credential subject, version and subject position,
+	// bumping its version and generating a fresh revocation nonce. When
+	// opts.RevokePrevious is set, the original claim is revoked through the
+	// jobs subsystem and the resulting job's id is returned alongside the
+	// renewed claim.
+	Renew(ctx context.Context, did *core.DID, claimID uuid.UUID, opts RenewOptions) (renewed *domain.Claim, revocationJobID *uuid.UUID, err error)
+	// SaveBatch persists claims in a single DB transaction: either all of
+	// them are saved, or none are.
+	SaveBatch(ctx context.Context, claims []*domain.Claim) error
+	// RecordIssuance stores req so the claim it describes can later be
+	// renewed via Renew. Callers save it right after Save/SaveBatch succeeds.
+	RecordIssuance(ctx context.Context, req IssuanceRequest) error
+}
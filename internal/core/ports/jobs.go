@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// JobsFilter narrows down a job listing by type and/or status.
+type JobsFilter struct {
+	Type   *domain.JobType
+	Status *domain.JobStatus
+}
+
+// JobsRepository persists and queries Job records.
+type JobsRepository interface {
+	Save(ctx context.Context, job *domain.Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	GetAll(ctx context.Context, filter JobsFilter) ([]domain.Job, error)
+	// ClaimPending atomically marks up to limit due pending/retrying jobs
+	// as running and returns them, so that concurrent callers never claim
+	// the same job twice.
+	ClaimPending(ctx context.Context, limit int) ([]domain.Job, error)
+}
+
+// JobsService enqueues jobs and exposes them for polling and administration.
+type JobsService interface {
+	// Enqueue persists a new pending job of the given type and returns it.
+	Enqueue(ctx context.Context, jobType domain.JobType, parms interface{}) (*domain.Job, error)
+	// GetByID returns a single job by its id.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// GetAll lists jobs matching the given filter.
+	GetAll(ctx context.Context, filter JobsFilter) ([]domain.Job, error)
+	// Retry resets a failed job back to pending so a worker picks it up again.
+	Retry(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+}
@@ -0,0 +1,91 @@
+// Package errs provides typed, HTTP-status-aware errors for the domain and
+// service layers so that transport code never has to guess which status
+// code a given failure maps to.
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// Error is a domain error that carries the HTTP status it should be
+// rendered with, plus a stack trace captured at the point it was created.
+type Error struct {
+	status int
+	msg    string
+	err    error
+	stack  string
+}
+
+// StatusCode implements the render.StatusCoder interface.
+func (e *Error) StatusCode() int {
+	return e.status
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("%s: %s", e.msg, e.err)
+	}
+	return e.msg
+}
+
+// Unwrap allows errors.Is / errors.As to see through to the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Stack returns the stack trace captured when the error was created. It is
+// meant for logging, never for the HTTP response body.
+func (e *Error) Stack() string {
+	return e.stack
+}
+
+func newError(status int, msg string, err error) *Error {
+	return &Error{
+		status: status,
+		msg:    msg,
+		err:    err,
+		stack:  string(debug.Stack()),
+	}
+}
+
+// BadRequest builds a 400 error with the given message.
+func BadRequest(msg string) *Error {
+	return newError(http.StatusBadRequest, msg, nil)
+}
+
+// NotFound builds a 404 error with the given message.
+func NotFound(msg string) *Error {
+	return newError(http.StatusNotFound, msg, nil)
+}
+
+// Unauthorized builds a 401 error with the given message.
+func Unauthorized(msg string) *Error {
+	return newError(http.StatusUnauthorized, msg, nil)
+}
+
+// Forbidden builds a 403 error with the given message.
+func Forbidden(msg string) *Error {
+	return newError(http.StatusForbidden, msg, nil)
+}
+
+// PayloadTooLarge builds a 413 error with the given message.
+func PayloadTooLarge(msg string) *Error {
+	return newError(http.StatusRequestEntityTooLarge, msg, nil)
+}
+
+// Internal builds a 500 error wrapping err.
+func Internal(err error) *Error {
+	return newError(http.StatusInternalServerError, "internal error", err)
+}
+
+// Wrap annotates err with msg, preserving err's HTTP status if it is already
+// a *Error, and defaulting to 500 otherwise.
+func Wrap(err error, msg string) *Error {
+	if e, ok := err.(*Error); ok {
+		return newError(e.status, msg, e)
+	}
+	return newError(http.StatusInternalServerError, msg, err)
+}
@@ -0,0 +1,12 @@
+package jobs
+
+// PublishStateParms are the parameters stored for a publish_state job.
+type PublishStateParms struct {
+	Identifier string `json:"identifier"`
+}
+
+// RevokeClaimParms are the parameters stored for a revoke_claim job.
+type RevokeClaimParms struct {
+	Identifier string `json:"identifier"`
+	Nonce      uint64 `json:"nonce"`
+}
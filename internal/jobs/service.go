@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// service is the default implementation of ports.JobsService.
+type service struct {
+	repo ports.JobsRepository
+}
+
+// NewService is a jobs service constructor.
+func NewService(repo ports.JobsRepository) ports.JobsService {
+	return &service{repo: repo}
+}
+
+func (s *service) Enqueue(ctx context.Context, jobType domain.JobType, parms interface{}) (*domain.Job, error) {
+	raw, err := json.Marshal(parms)
+	if err != nil {
+		log.Error(ctx, "can not marshal job parms", err)
+		return nil, err
+	}
+
+	job := domain.NewJob(jobType, raw)
+	if err := s.repo.Save(ctx, job); err != nil {
+		log.Error(ctx, "can not save job", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *service) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetAll(ctx context.Context, filter ports.JobsFilter) ([]domain.Job, error) {
+	return s.repo.GetAll(ctx, filter)
+}
+
+func (s *service) Retry(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = domain.JobStatusPending
+	job.Error = nil
+	job.NextAttemptAt = time.Now()
+	if err := s.repo.Save(ctx, job); err != nil {
+		log.Error(ctx, "can not save retried job", err)
+		return nil, err
+	}
+	return job, nil
+}
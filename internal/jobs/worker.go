@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// Handler executes the work for a single job, given its raw parms.
+type Handler func(ctx context.Context, parms json.RawMessage) error
+
+// backoffSchedule are the delays applied between retries, in order. The last
+// entry is reused for any attempt beyond its index.
+var backoffSchedule = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+}
+
+const maxAttempts = len(backoffSchedule) + 1
+
+// OnSuccess is called with a job that just finished successfully, after its
+// succeeded status has been persisted. Used to chain follow-up side effects
+// off specific job types (e.g. triggering replication once a revocation or
+// state publish actually completes) without the worker pool needing to know
+// anything about those side effects itself.
+type OnSuccess func(ctx context.Context, job *domain.Job)
+
+// WorkerPool polls the jobs repository for pending work and dispatches it to
+// the registered handler for each job type.
+type WorkerPool struct {
+	repo        ports.JobsRepository
+	handlers    map[domain.JobType]Handler
+	onSuccess   []OnSuccess
+	pollEvery   time.Duration
+	concurrency int
+
+	wg       sync.WaitGroup
+	inFlight sync.Map // job id -> *domain.Job, for graceful shutdown persistence
+	stop     chan struct{}
+}
+
+// NewWorkerPool is a WorkerPool constructor.
+func NewWorkerPool(repo ports.JobsRepository, concurrency int, pollEvery time.Duration) *WorkerPool {
+	return &WorkerPool{
+		repo:        repo,
+		handlers:    make(map[domain.JobType]Handler),
+		pollEvery:   pollEvery,
+		concurrency: concurrency,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Register associates a Handler with a job type. Call before Start.
+func (p *WorkerPool) Register(jobType domain.JobType, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// RegisterOnSuccess adds a callback invoked for every job that completes
+// successfully. Call before Start.
+func (p *WorkerPool) RegisterOnSuccess(hook OnSuccess) {
+	p.onSuccess = append(p.onSuccess, hook)
+}
+
+// Start launches the worker goroutines. It returns immediately; call
+// Shutdown to stop the workers and persist any in-flight jobs.
+func (p *WorkerPool) Start(ctx context.Context) {
+	for i := 0; i < p.concurrency; i++ {
+		p.wg.Add(1)
+		go p.run(ctx)
+	}
+}
+
+// Shutdown stops accepting new work, waits for in-flight jobs to finish
+// persisting their state, and returns once all workers have exited. If ctx
+// is done first, it saves every in-flight job as it stands and returns
+// without waiting for the workers.
+func (p *WorkerPool) Shutdown(ctx context.Context) {
+	close(p.stop)
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn(ctx, "jobs worker pool shutdown timed out, persisting in-flight jobs")
+		p.inFlight.Range(func(_, v interface{}) bool {
+			job := v.(*domain.Job)
+			_ = p.repo.Save(context.Background(), job)
+			return true
+		})
+	}
+}
+
+func (p *WorkerPool) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.processOnce(ctx)
+		}
+	}
+}
+
+func (p *WorkerPool) processOnce(ctx context.Context) {
+	claimed, err := p.repo.ClaimPending(ctx, 1)
+	if err != nil {
+		log.Error(ctx, "can not claim pending jobs", err)
+		return
+	}
+
+	for i := range claimed {
+		job := claimed[i]
+		p.execute(ctx, &job)
+	}
+}
+
+// execute runs the handler for an already-claimed (status=running) job and
+// persists its outcome. On failure it schedules the next attempt by
+// setting NextAttemptAt to the end of the backoff delay and leaving the
+// job in "retrying"; ClaimPending's next_attempt_at <= now() filter is
+// what gates the job from being picked up again before then.
+func (p *WorkerPool) execute(ctx context.Context, job *domain.Job) {
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		log.Error(ctx, "no handler registered for job type, failing job", "type", job.Type)
+		errMsg := fmt.Sprintf("no handler registered for job type %q", job.Type)
+		job.Status = domain.JobStatusFailed
+		job.Error = &errMsg
+		job.UpdateTime = time.Now()
+		if err := p.repo.Save(ctx, job); err != nil {
+			log.Error(ctx, "can not save job result", err)
+		}
+		return
+	}
+
+	p.inFlight.Store(job.ID, job)
+	defer p.inFlight.Delete(job.ID)
+
+	if err := handler(ctx, job.Parms); err != nil {
+		job.Attempts++
+		errMsg := err.Error()
+		job.Error = &errMsg
+
+		if job.Attempts >= maxAttempts {
+			job.Status = domain.JobStatusFailed
+		} else {
+			job.Status = domain.JobStatusRetrying
+			job.NextAttemptAt = time.Now().Add(backoffDelay(job.Attempts))
+		}
+	} else {
+		job.Status = domain.JobStatusSucceeded
+		job.Error = nil
+	}
+
+	job.UpdateTime = time.Now()
+	if err := p.repo.Save(ctx, job); err != nil {
+		log.Error(ctx, "can not save job result", err)
+	}
+
+	if job.Status == domain.JobStatusSucceeded {
+		for _, hook := range p.onSuccess {
+			hook(ctx, job)
+		}
+	}
+}
+
+func backoffDelay(attempt int) time.Duration {
+	if attempt <= 0 {
+		return backoffSchedule[0]
+	}
+	if attempt > len(backoffSchedule) {
+		return backoffSchedule[len(backoffSchedule)-1]
+	}
+	return backoffSchedule[attempt-1]
+}
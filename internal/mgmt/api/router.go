@@ -0,0 +1,226 @@
+// Package api exposes the mgmt subsystem over HTTP, mounted under
+// /admin/v1 on the main chi mux. It is deliberately separate from the
+// oapi-codegen-generated credential issuance surface in internal/api,
+// since it is operated by issuer admins rather than wallets/verifiers.
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	"github.com/polygonid/sh-id-platform/internal/api/render"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/mgmt"
+)
+
+// RegisterRoutes mounts the management endpoints under /admin/v1 on mux,
+// gated behind the admin scope. Callers are expected to have already
+// mounted auth.Middleware on mux.
+func RegisterRoutes(mux *chi.Mux, svc *mgmt.Service) {
+	mux.Route("/admin/v1", func(r chi.Router) {
+		r.Use(auth.RequireScope(auth.ScopeAdmin))
+
+		r.Route("/schemas", func(r chi.Router) {
+			r.Post("/", createSchema(svc))
+			r.Get("/", listSchemas(svc))
+			r.Delete("/{id}", deleteSchema(svc))
+		})
+
+		r.Route("/issuance-policies", func(r chi.Router) {
+			r.Put("/{issuerDID}", putIssuancePolicy(svc))
+			r.Get("/{issuerDID}", getIssuancePolicy(svc))
+			r.Delete("/{id}", deleteIssuancePolicy(svc))
+		})
+
+		r.Route("/identity-defaults", func(r chi.Router) {
+			r.Put("/{issuerDID}", putIdentityDefaults(svc))
+			r.Get("/{issuerDID}", getIdentityDefaults(svc))
+		})
+	})
+}
+
+type createSchemaRequest struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+func createSchema(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := r.URL.Query().Get("issuerDID")
+		if issuerDID == "" {
+			render.Error(w, r, errs.BadRequest("issuerDID query parameter is required"))
+			return
+		}
+
+		req := createSchemaRequest{}
+		if err := decodeJSON(r, &req); err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		schema, err := svc.RegisterSchema(r.Context(), issuerDID, req.URL, req.Type)
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, schema)
+	}
+}
+
+func listSchemas(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := r.URL.Query().Get("issuerDID")
+		if issuerDID == "" {
+			render.Error(w, r, errs.BadRequest("issuerDID query parameter is required"))
+			return
+		}
+
+		schemas, err := svc.Schemas(r.Context(), issuerDID)
+		if err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not list schemas"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, schemas)
+	}
+}
+
+func deleteSchema(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid schema id"))
+			return
+		}
+
+		if err := svc.DeleteSchema(r.Context(), id); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not delete schema"))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type issuancePolicyRequest struct {
+	AllowedTypes                []string `json:"allowedTypes"`
+	MaxExpirationSeconds        *int64   `json:"maxExpirationSeconds"`
+	RequiredSubjectFields       []string `json:"requiredSubjectFields"`
+	DefaultMerklizedRootPostion string   `json:"defaultMerklizedRootPosition"`
+	AllowRenewAfterExpiry       bool     `json:"allowRenewAfterExpiry"`
+	RenewAfterExpiryGraceSecs   int64    `json:"renewAfterExpiryGraceSeconds"`
+}
+
+func putIssuancePolicy(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := chi.URLParam(r, "issuerDID")
+
+		req := issuancePolicyRequest{}
+		if err := decodeJSON(r, &req); err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		policy := &mgmt.IssuancePolicy{
+			IssuerDID:                   issuerDID,
+			AllowedTypes:                req.AllowedTypes,
+			RequiredSubjectFields:       req.RequiredSubjectFields,
+			DefaultMerklizedRootPostion: req.DefaultMerklizedRootPostion,
+			AllowRenewAfterExpiry:       req.AllowRenewAfterExpiry,
+			RenewAfterExpiryGrace:       secondsToDuration(req.RenewAfterExpiryGraceSecs),
+		}
+		if req.MaxExpirationSeconds != nil {
+			d := secondsToDuration(*req.MaxExpirationSeconds)
+			policy.MaxExpiration = &d
+		}
+
+		if err := svc.SetIssuancePolicy(r.Context(), policy); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not save issuance policy"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, policy)
+	}
+}
+
+func getIssuancePolicy(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := chi.URLParam(r, "issuerDID")
+
+		policy, err := svc.IssuancePolicy(r.Context(), issuerDID)
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, policy)
+	}
+}
+
+func deleteIssuancePolicy(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid issuance policy id"))
+			return
+		}
+
+		if err := svc.DeleteIssuancePolicy(r.Context(), id); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not delete issuance policy"))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type identityDefaultsRequest struct {
+	DefaultExpirationSeconds *int64 `json:"defaultExpirationSeconds"`
+	DefaultSubjectPosition   string `json:"defaultSubjectPosition"`
+}
+
+func putIdentityDefaults(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := chi.URLParam(r, "issuerDID")
+
+		req := identityDefaultsRequest{}
+		if err := decodeJSON(r, &req); err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		defaults := &mgmt.IdentityDefaults{
+			IssuerDID:             issuerDID,
+			DefaultSubjectPostion: req.DefaultSubjectPosition,
+		}
+		if req.DefaultExpirationSeconds != nil {
+			d := secondsToDuration(*req.DefaultExpirationSeconds)
+			defaults.DefaultExpiration = &d
+		}
+
+		if err := svc.SetIdentityDefaults(r.Context(), defaults); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not save identity defaults"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, defaults)
+	}
+}
+
+func getIdentityDefaults(svc *mgmt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := chi.URLParam(r, "issuerDID")
+
+		defaults, err := svc.IdentityDefaults(r.Context(), issuerDID)
+		if err != nil {
+			render.Error(w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, defaults)
+	}
+}
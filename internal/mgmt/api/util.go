@@ -0,0 +1,16 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func secondsToDuration(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
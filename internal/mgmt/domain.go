@@ -0,0 +1,54 @@
+// Package mgmt holds the management/admin subsystem: schemas, per-issuer
+// issuance policies and identity provisioning defaults that can be
+// configured at runtime instead of being baked in at deploy time.
+package mgmt
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is a credential schema an issuer has registered for use.
+type Schema struct {
+	ID        uuid.UUID
+	IssuerDID string
+	URL       string
+	Type      string
+	CreatedAt time.Time
+}
+
+// IssuancePolicy constrains how claims may be issued for a given issuer DID.
+type IssuancePolicy struct {
+	ID                          uuid.UUID
+	IssuerDID                   string
+	AllowedTypes                []string
+	MaxExpiration               *time.Duration
+	RequiredSubjectFields       []string
+	DefaultMerklizedRootPostion string
+	AllowRenewAfterExpiry       bool
+	RenewAfterExpiryGrace       time.Duration
+}
+
+// AllowsType reports whether credType is permitted by the policy. An empty
+// AllowedTypes means every type is allowed.
+func (p IssuancePolicy) AllowsType(credType string) bool {
+	if len(p.AllowedTypes) == 0 {
+		return true
+	}
+	for _, t := range p.AllowedTypes {
+		if t == credType {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentityDefaults are the identity provisioning defaults stored per issuer
+// DID and managed through the admin API. Identity creation does not read
+// or apply them.
+type IdentityDefaults struct {
+	IssuerDID             string
+	DefaultExpiration     *time.Duration
+	DefaultSubjectPostion string
+}
@@ -0,0 +1,23 @@
+package mgmt
+
+import "time"
+
+func durationSecondsPtr(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+	seconds := int(d.Seconds())
+	return &seconds
+}
+
+func secondsPtrToDuration(seconds *int) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+	d := time.Duration(*seconds) * time.Second
+	return &d
+}
+
+func secondsToDuration(seconds int) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,155 @@
+package mgmt
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// ErrSchemaDoesNotExist is returned when a schema cannot be found by id.
+var ErrSchemaDoesNotExist = errs.NotFound("the schema does not exist")
+
+// ErrIssuancePolicyDoesNotExist is returned when no issuance policy has
+// been configured for an issuer DID.
+var ErrIssuancePolicyDoesNotExist = errs.NotFound("the issuance policy does not exist")
+
+// ErrIdentityDefaultsDoesNotExist is returned when no identity
+// provisioning defaults have been configured for an issuer DID.
+var ErrIdentityDefaultsDoesNotExist = errs.NotFound("the identity defaults are not configured")
+
+// pgStore is the postgres-backed implementation of Store.
+type pgStore struct{}
+
+// NewPostgresStore is a Store constructor.
+func NewPostgresStore() Store {
+	return &pgStore{}
+}
+
+func (s *pgStore) SaveSchema(ctx context.Context, schema *Schema) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO mgmt_schemas (id, issuer_did, url, type, created_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO UPDATE SET url = $3, type = $4`,
+		schema.ID, schema.IssuerDID, schema.URL, schema.Type, schema.CreatedAt)
+	return err
+}
+
+func (s *pgStore) GetSchema(ctx context.Context, id uuid.UUID) (*Schema, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT id, issuer_did, url, type, created_at FROM mgmt_schemas WHERE id = $1`, id)
+
+	schema := Schema{}
+	if err := row.Scan(&schema.ID, &schema.IssuerDID, &schema.URL, &schema.Type, &schema.CreatedAt); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrSchemaDoesNotExist
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (s *pgStore) GetSchemas(ctx context.Context, issuerDID string) ([]Schema, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, issuer_did, url, type, created_at FROM mgmt_schemas WHERE issuer_did = $1 ORDER BY created_at DESC`,
+		issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schemas := make([]Schema, 0)
+	for rows.Next() {
+		schema := Schema{}
+		if err := rows.Scan(&schema.ID, &schema.IssuerDID, &schema.URL, &schema.Type, &schema.CreatedAt); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, rows.Err()
+}
+
+func (s *pgStore) DeleteSchema(ctx context.Context, id uuid.UUID) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx, `DELETE FROM mgmt_schemas WHERE id = $1`, id)
+	return err
+}
+
+func (s *pgStore) SaveIssuancePolicy(ctx context.Context, policy *IssuancePolicy) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO mgmt_issuance_policies
+		   (id, issuer_did, allowed_types, max_expiration_seconds, required_subject_fields,
+		    default_merklized_root_position, allow_renew_after_expiry, renew_after_expiry_grace_seconds)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (issuer_did) DO UPDATE SET
+		   allowed_types = $3, max_expiration_seconds = $4, required_subject_fields = $5,
+		   default_merklized_root_position = $6, allow_renew_after_expiry = $7, renew_after_expiry_grace_seconds = $8`,
+		policy.ID, policy.IssuerDID, policy.AllowedTypes, durationSecondsPtr(policy.MaxExpiration),
+		policy.RequiredSubjectFields, policy.DefaultMerklizedRootPostion, policy.AllowRenewAfterExpiry,
+		int(policy.RenewAfterExpiryGrace.Seconds()))
+	return err
+}
+
+func (s *pgStore) GetIssuancePolicyByIssuer(ctx context.Context, issuerDID string) (*IssuancePolicy, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT id, issuer_did, allowed_types, max_expiration_seconds, required_subject_fields,
+		        default_merklized_root_position, allow_renew_after_expiry, renew_after_expiry_grace_seconds
+		 FROM mgmt_issuance_policies WHERE issuer_did = $1`, issuerDID)
+
+	var maxExpirationSeconds *int
+	var renewGraceSeconds int
+	policy := IssuancePolicy{}
+	if err := row.Scan(&policy.ID, &policy.IssuerDID, &policy.AllowedTypes, &maxExpirationSeconds,
+		&policy.RequiredSubjectFields, &policy.DefaultMerklizedRootPostion, &policy.AllowRenewAfterExpiry,
+		&renewGraceSeconds); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrIssuancePolicyDoesNotExist
+		}
+		return nil, err
+	}
+	policy.MaxExpiration = secondsPtrToDuration(maxExpirationSeconds)
+	policy.RenewAfterExpiryGrace = secondsToDuration(renewGraceSeconds)
+	return &policy, nil
+}
+
+func (s *pgStore) DeleteIssuancePolicy(ctx context.Context, id uuid.UUID) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx, `DELETE FROM mgmt_issuance_policies WHERE id = $1`, id)
+	return err
+}
+
+func (s *pgStore) SaveIdentityDefaults(ctx context.Context, defaults *IdentityDefaults) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO mgmt_identity_defaults (issuer_did, default_expiration_seconds, default_subject_position)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (issuer_did) DO UPDATE SET
+		   default_expiration_seconds = $2, default_subject_position = $3`,
+		defaults.IssuerDID, durationSecondsPtr(defaults.DefaultExpiration), defaults.DefaultSubjectPostion)
+	return err
+}
+
+func (s *pgStore) GetIdentityDefaults(ctx context.Context, issuerDID string) (*IdentityDefaults, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT issuer_did, default_expiration_seconds, default_subject_position
+		 FROM mgmt_identity_defaults WHERE issuer_did = $1`, issuerDID)
+
+	var defaultExpirationSeconds *int
+	defaults := IdentityDefaults{}
+	if err := row.Scan(&defaults.IssuerDID, &defaultExpirationSeconds, &defaults.DefaultSubjectPostion); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrIdentityDefaultsDoesNotExist
+		}
+		return nil, err
+	}
+	defaults.DefaultExpiration = secondsPtrToDuration(defaultExpirationSeconds)
+	return &defaults, nil
+}
@@ -0,0 +1,118 @@
+package mgmt
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// ClaimRequest is the subset of a claim creation request the issuance
+// policy needs to validate. It is intentionally decoupled from
+// ports.ClaimRequest so this package does not depend on the schema
+// processor types.
+type ClaimRequest struct {
+	Type              string
+	Expiration        *int64 // unix seconds, nil means no expiration
+	CredentialSubject map[string]interface{}
+}
+
+// Service is the management subsystem's application service: CRUD over
+// schemas/policies plus the policy check CreateClaim relies on.
+type Service struct {
+	store Store
+}
+
+// NewService is a Service constructor.
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// RegisterSchema stores a new schema for issuerDID.
+func (s *Service) RegisterSchema(ctx context.Context, issuerDID, url, schemaType string) (*Schema, error) {
+	schema := &Schema{
+		ID:        uuid.New(),
+		IssuerDID: issuerDID,
+		URL:       url,
+		Type:      schemaType,
+		CreatedAt: time.Now(),
+	}
+	if err := s.store.SaveSchema(ctx, schema); err != nil {
+		return nil, errs.Wrap(errs.Internal(err), "can not save schema")
+	}
+	return schema, nil
+}
+
+// Schemas lists the schemas registered for issuerDID.
+func (s *Service) Schemas(ctx context.Context, issuerDID string) ([]Schema, error) {
+	return s.store.GetSchemas(ctx, issuerDID)
+}
+
+// DeleteSchema removes a previously registered schema.
+func (s *Service) DeleteSchema(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeleteSchema(ctx, id)
+}
+
+// SetIssuancePolicy creates or replaces the issuance policy for an issuer DID.
+func (s *Service) SetIssuancePolicy(ctx context.Context, policy *IssuancePolicy) error {
+	if policy.ID == uuid.Nil {
+		policy.ID = uuid.New()
+	}
+	return s.store.SaveIssuancePolicy(ctx, policy)
+}
+
+// IssuancePolicy returns the policy configured for issuerDID.
+func (s *Service) IssuancePolicy(ctx context.Context, issuerDID string) (*IssuancePolicy, error) {
+	return s.store.GetIssuancePolicyByIssuer(ctx, issuerDID)
+}
+
+// DeleteIssuancePolicy removes the issuance policy with the given id.
+func (s *Service) DeleteIssuancePolicy(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeleteIssuancePolicy(ctx, id)
+}
+
+// SetIdentityDefaults creates or replaces the identity provisioning
+// defaults for an issuer DID.
+func (s *Service) SetIdentityDefaults(ctx context.Context, defaults *IdentityDefaults) error {
+	return s.store.SaveIdentityDefaults(ctx, defaults)
+}
+
+// IdentityDefaults returns the provisioning defaults configured for issuerDID.
+func (s *Service) IdentityDefaults(ctx context.Context, issuerDID string) (*IdentityDefaults, error) {
+	return s.store.GetIdentityDefaults(ctx, issuerDID)
+}
+
+// ValidateClaimRequest checks req against the issuance policy configured
+// for issuerDID, rejecting it with a typed 400 error on the first
+// violation. An issuer with no configured policy is unrestricted.
+func (s *Service) ValidateClaimRequest(ctx context.Context, issuerDID string, req ClaimRequest) error {
+	policy, err := s.store.GetIssuancePolicyByIssuer(ctx, issuerDID)
+	if err != nil {
+		if errors.Is(err, ErrIssuancePolicyDoesNotExist) {
+			return nil
+		}
+		return errs.Wrap(errs.Internal(err), "can not load issuance policy")
+	}
+
+	if !policy.AllowsType(req.Type) {
+		return errs.BadRequest("credential type '" + req.Type + "' is not allowed by the issuance policy")
+	}
+
+	if policy.MaxExpiration != nil && req.Expiration != nil {
+		maxExpiresAt := time.Now().Add(*policy.MaxExpiration).Unix()
+		if *req.Expiration > maxExpiresAt {
+			return errs.BadRequest("requested expiration exceeds the issuance policy's maximum allowed expiration")
+		}
+	}
+
+	for _, field := range policy.RequiredSubjectFields {
+		if _, ok := req.CredentialSubject[field]; !ok {
+			return errs.BadRequest("credentialSubject is missing required field '" + field + "'")
+		}
+	}
+
+	return nil
+}
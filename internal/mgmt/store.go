@@ -0,0 +1,22 @@
+package mgmt
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Store is the DB-backed persistence layer for the management subsystem.
+type Store interface {
+	SaveSchema(ctx context.Context, schema *Schema) error
+	GetSchema(ctx context.Context, id uuid.UUID) (*Schema, error)
+	GetSchemas(ctx context.Context, issuerDID string) ([]Schema, error)
+	DeleteSchema(ctx context.Context, id uuid.UUID) error
+
+	SaveIssuancePolicy(ctx context.Context, policy *IssuancePolicy) error
+	GetIssuancePolicyByIssuer(ctx context.Context, issuerDID string) (*IssuancePolicy, error)
+	DeleteIssuancePolicy(ctx context.Context, id uuid.UUID) error
+
+	SaveIdentityDefaults(ctx context.Context, defaults *IdentityDefaults) error
+	GetIdentityDefaults(ctx context.Context, issuerDID string) (*IdentityDefaults, error)
+}
@@ -0,0 +1,205 @@
+// Package api exposes the replication subsystem's CRUD and audit log over
+// HTTP, mounted under /admin/v1/replication.
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/api/auth"
+	"github.com/polygonid/sh-id-platform/internal/api/render"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+	"github.com/polygonid/sh-id-platform/internal/replication"
+)
+
+// RegisterRoutes mounts the replication endpoints under
+// /admin/v1/replication on mux, gated behind the admin scope. Callers are
+// expected to have already mounted auth.Middleware on mux.
+func RegisterRoutes(mux *chi.Mux, svc *replication.Service) {
+	mux.Route("/admin/v1/replication", func(r chi.Router) {
+		r.Use(auth.RequireScope(auth.ScopeAdmin))
+
+		r.Route("/policies", func(r chi.Router) {
+			r.Put("/", putPolicy(svc))
+			r.Get("/", listPolicies(svc))
+			r.Delete("/{id}", deletePolicy(svc))
+		})
+
+		r.Route("/targets", func(r chi.Router) {
+			r.Put("/", putTarget(svc))
+			r.Delete("/{id}", deleteTarget(svc))
+		})
+
+		r.Get("/log", getLog(svc))
+	})
+}
+
+type policyRequest struct {
+	ID                    string                `json:"id,omitempty"`
+	IssuerDID             string                `json:"issuerDid"`
+	Name                  string                `json:"name"`
+	TargetIDs             []string              `json:"targetIds"`
+	Triggers              []replication.Trigger `json:"triggers"`
+	FilterCredentialTypes []string              `json:"filterCredentialTypes"`
+	Schedule              string                `json:"schedule,omitempty"`
+}
+
+func putPolicy(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := policyRequest{}
+		if err := decodeJSON(r, &req); err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		targetIDs := make([]uuid.UUID, 0, len(req.TargetIDs))
+		for _, raw := range req.TargetIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				render.Error(w, r, errs.BadRequest("invalid target id: "+raw))
+				return
+			}
+			targetIDs = append(targetIDs, id)
+		}
+
+		policy := &replication.Policy{
+			IssuerDID:             req.IssuerDID,
+			Name:                  req.Name,
+			TargetIDs:             targetIDs,
+			Triggers:              req.Triggers,
+			FilterCredentialTypes: req.FilterCredentialTypes,
+			Schedule:              req.Schedule,
+		}
+		if req.ID != "" {
+			id, err := uuid.Parse(req.ID)
+			if err != nil {
+				render.Error(w, r, errs.BadRequest("invalid policy id"))
+				return
+			}
+			policy.ID = id
+		}
+
+		if err := svc.SavePolicy(r.Context(), policy); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not save replication policy"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, policy)
+	}
+}
+
+func listPolicies(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuerDID := r.URL.Query().Get("issuerDID")
+		if issuerDID == "" {
+			render.Error(w, r, errs.BadRequest("issuerDID query parameter is required"))
+			return
+		}
+
+		policies, err := svc.Policies(r.Context(), issuerDID)
+		if err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not list replication policies"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, policies)
+	}
+}
+
+func deletePolicy(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid policy id"))
+			return
+		}
+
+		if err := svc.DeletePolicy(r.Context(), id); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not delete replication policy"))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type targetRequest struct {
+	ID        string `json:"id,omitempty"`
+	IssuerDID string `json:"issuerDid"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	AuthToken string `json:"authToken,omitempty"`
+}
+
+func putTarget(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := targetRequest{}
+		if err := decodeJSON(r, &req); err != nil {
+			render.Error(w, r, errs.BadRequest(err.Error()))
+			return
+		}
+
+		target := &replication.Target{
+			IssuerDID: req.IssuerDID,
+			Name:      req.Name,
+			URL:       req.URL,
+			AuthToken: req.AuthToken,
+		}
+		if req.ID != "" {
+			id, err := uuid.Parse(req.ID)
+			if err != nil {
+				render.Error(w, r, errs.BadRequest("invalid target id"))
+				return
+			}
+			target.ID = id
+		}
+
+		if err := svc.SaveTarget(r.Context(), target); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not save replication target"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, target)
+	}
+}
+
+func deleteTarget(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Error(w, r, errs.BadRequest("invalid target id"))
+			return
+		}
+
+		if err := svc.DeleteTarget(r.Context(), id); err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not delete replication target"))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getLog(svc *replication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := replication.LogFilter{}
+		if raw := r.URL.Query().Get("policy"); raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				render.Error(w, r, errs.BadRequest("invalid policy id"))
+				return
+			}
+			filter.PolicyID = &id
+		}
+
+		entries, err := svc.Log(r.Context(), filter)
+		if err != nil {
+			render.Error(w, r, errs.Wrap(err, "can not list replication log"))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, entries)
+	}
+}
@@ -0,0 +1,11 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
@@ -0,0 +1,91 @@
+// Package replication lets an admin push issued credentials and published
+// state to remote relay/backup nodes, so hot-standby issuers or downstream
+// wallet-provisioning services can stay in sync without touching the
+// issuance code path itself.
+package replication
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trigger is the event that causes a Policy to replicate.
+type Trigger string
+
+const (
+	// TriggerOnIssue fires right after CreateClaim has saved a claim. Claims
+	// saved by batch issuance or by renewal do not fire it.
+	TriggerOnIssue Trigger = "on_issue"
+	// TriggerOnRevoke fires right after a claim has been revoked.
+	TriggerOnRevoke Trigger = "on_revoke"
+	// TriggerOnStatePublish fires right after an identity state publish succeeds.
+	TriggerOnStatePublish Trigger = "on_state_publish"
+	// TriggerScheduled fires at a fixed interval (Policy.Schedule, a Go
+	// duration) rather than in response to an event. Cron expressions are not
+	// supported.
+	TriggerScheduled Trigger = "scheduled"
+)
+
+// Target is a remote node credentials/state get replicated to.
+type Target struct {
+	ID        uuid.UUID
+	IssuerDID string
+	Name      string
+	URL       string
+	AuthToken string
+	CreatedAt time.Time
+}
+
+// Policy binds an issuer DID to one or more Targets, firing on the given
+// Triggers and optionally filtered by credential type. There is no filter
+// by credential subject.
+type Policy struct {
+	ID                    uuid.UUID
+	IssuerDID             string
+	Name                  string
+	TargetIDs             []uuid.UUID
+	Triggers              []Trigger
+	FilterCredentialTypes []string
+	Schedule              string // interval (e.g. "1h", "15m"), only meaningful for TriggerScheduled
+	CreatedAt             time.Time
+	// LastRunAt is when a TriggerScheduled policy last fired. Nil means it
+	// has never run yet. Unused by event-triggered policies.
+	LastRunAt *time.Time
+}
+
+// FiresOn reports whether the policy replicates for the given trigger.
+func (p Policy) FiresOn(trigger Trigger) bool {
+	for _, t := range p.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether credType passes the policy's type filter. An empty
+// filter means every type is replicated.
+func (p Policy) Allows(credType string) bool {
+	if len(p.FilterCredentialTypes) == 0 {
+		return true
+	}
+	for _, t := range p.FilterCredentialTypes {
+		if t == credType {
+			return true
+		}
+	}
+	return false
+}
+
+// LogEntry records the outcome of replicating one claim to one target.
+type LogEntry struct {
+	ID        uuid.UUID
+	PolicyID  uuid.UUID
+	TargetID  uuid.UUID
+	ClaimID   string
+	Trigger   Trigger
+	Success   bool
+	Error     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,215 @@
+package replication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// ErrPolicyDoesNotExist is returned when a replication policy cannot be found.
+var ErrPolicyDoesNotExist = errs.NotFound("the replication policy does not exist")
+
+// ErrTargetDoesNotExist is returned when a replication target cannot be found.
+var ErrTargetDoesNotExist = errs.NotFound("the replication target does not exist")
+
+type pgStore struct{}
+
+// NewPostgresStore is a Store constructor.
+func NewPostgresStore() Store {
+	return &pgStore{}
+}
+
+func (s *pgStore) SavePolicy(ctx context.Context, policy *Policy) error {
+	conn := db.FromContext(ctx)
+	triggers := make([]string, len(policy.Triggers))
+	for i, t := range policy.Triggers {
+		triggers[i] = string(t)
+	}
+	_, err := conn.Exec(ctx,
+		`INSERT INTO replication_policies
+		   (id, issuer_did, name, target_ids, triggers, filter_credential_types, schedule, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (id) DO UPDATE SET
+		   name = $3, target_ids = $4, triggers = $5, filter_credential_types = $6, schedule = $7`,
+		policy.ID, policy.IssuerDID, policy.Name, pq.Array(policy.TargetIDs), pq.Array(triggers),
+		pq.Array(policy.FilterCredentialTypes), policy.Schedule, policy.CreatedAt)
+	return err
+}
+
+func (s *pgStore) GetPolicy(ctx context.Context, id uuid.UUID) (*Policy, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT id, issuer_did, name, target_ids, triggers, filter_credential_types, schedule, created_at, last_run_at
+		 FROM replication_policies WHERE id = $1`, id)
+	return scanPolicy(row)
+}
+
+func (s *pgStore) GetPoliciesByIssuer(ctx context.Context, issuerDID string) ([]Policy, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, issuer_did, name, target_ids, triggers, filter_credential_types, schedule, created_at, last_run_at
+		 FROM replication_policies WHERE issuer_did = $1 ORDER BY created_at DESC`, issuerDID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]Policy, 0)
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+	return policies, rows.Err()
+}
+
+func (s *pgStore) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx, `DELETE FROM replication_policies WHERE id = $1`, id)
+	return err
+}
+
+// GetScheduledPolicies returns every policy with TriggerScheduled among its
+// triggers, regardless of issuer, so the scheduler can check each one's due
+// time in one pass.
+func (s *pgStore) GetScheduledPolicies(ctx context.Context) ([]Policy, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, issuer_did, name, target_ids, triggers, filter_credential_types, schedule, created_at, last_run_at
+		 FROM replication_policies WHERE $1 = ANY(triggers)`, string(TriggerScheduled))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]Policy, 0)
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+	return policies, rows.Err()
+}
+
+func (s *pgStore) MarkPolicyRun(ctx context.Context, id uuid.UUID, at time.Time) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx, `UPDATE replication_policies SET last_run_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+// rowScanner is satisfied by both pgx.Row and pgx.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row rowScanner) (*Policy, error) {
+	var triggers, filterTypes []string
+	policy := Policy{}
+	if err := row.Scan(&policy.ID, &policy.IssuerDID, &policy.Name, pq.Array(&policy.TargetIDs),
+		pq.Array(&triggers), pq.Array(&filterTypes), &policy.Schedule, &policy.CreatedAt, &policy.LastRunAt); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrPolicyDoesNotExist
+		}
+		return nil, err
+	}
+	policy.Triggers = make([]Trigger, len(triggers))
+	for i, t := range triggers {
+		policy.Triggers[i] = Trigger(t)
+	}
+	policy.FilterCredentialTypes = filterTypes
+	return &policy, nil
+}
+
+func (s *pgStore) SaveTarget(ctx context.Context, target *Target) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO replication_targets (id, issuer_did, name, url, auth_token, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (id) DO UPDATE SET name = $3, url = $4, auth_token = $5`,
+		target.ID, target.IssuerDID, target.Name, target.URL, target.AuthToken, target.CreatedAt)
+	return err
+}
+
+func (s *pgStore) GetTarget(ctx context.Context, id uuid.UUID) (*Target, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT id, issuer_did, name, url, auth_token, created_at FROM replication_targets WHERE id = $1`, id)
+
+	target := Target{}
+	if err := row.Scan(&target.ID, &target.IssuerDID, &target.Name, &target.URL, &target.AuthToken, &target.CreatedAt); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrTargetDoesNotExist
+		}
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (s *pgStore) GetTargets(ctx context.Context, ids []uuid.UUID) ([]Target, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, issuer_did, name, url, auth_token, created_at FROM replication_targets WHERE id = ANY($1)`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := make([]Target, 0, len(ids))
+	for rows.Next() {
+		target := Target{}
+		if err := rows.Scan(&target.ID, &target.IssuerDID, &target.Name, &target.URL, &target.AuthToken, &target.CreatedAt); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, rows.Err()
+}
+
+func (s *pgStore) DeleteTarget(ctx context.Context, id uuid.UUID) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx, `DELETE FROM replication_targets WHERE id = $1`, id)
+	return err
+}
+
+func (s *pgStore) SaveLogEntry(ctx context.Context, entry *LogEntry) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO replication_log (id, policy_id, target_id, claim_id, trigger, success, error, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		entry.ID, entry.PolicyID, entry.TargetID, entry.ClaimID, entry.Trigger, entry.Success, entry.Error, entry.CreatedAt)
+	return err
+}
+
+func (s *pgStore) GetLog(ctx context.Context, filter LogFilter) ([]LogEntry, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, policy_id, target_id, claim_id, trigger, success, error, created_at
+		 FROM replication_log
+		 WHERE ($1::uuid IS NULL OR policy_id = $1)
+		 ORDER BY created_at DESC`, filter.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]LogEntry, 0)
+	for rows.Next() {
+		entry := LogEntry{}
+		if err := rows.Scan(&entry.ID, &entry.PolicyID, &entry.TargetID, &entry.ClaimID, &entry.Trigger,
+			&entry.Success, &entry.Error, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
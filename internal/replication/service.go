@@ -0,0 +1,205 @@
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/jobs"
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// Service is the replication subsystem's application service: CRUD over
+// policies/targets plus the trigger CreateClaim (and friends) call after a
+// successful mutation.
+type Service struct {
+	store       Store
+	jobsService ports.JobsService
+}
+
+// NewService is a Service constructor.
+func NewService(store Store, jobsService ports.JobsService) *Service {
+	return &Service{store: store, jobsService: jobsService}
+}
+
+// SavePolicy creates or replaces a replication policy.
+func (s *Service) SavePolicy(ctx context.Context, policy *Policy) error {
+	if policy.ID == uuid.Nil {
+		policy.ID = uuid.New()
+	}
+	if policy.CreatedAt.IsZero() {
+		policy.CreatedAt = time.Now()
+	}
+	return s.store.SavePolicy(ctx, policy)
+}
+
+// Policies lists the replication policies configured for issuerDID.
+func (s *Service) Policies(ctx context.Context, issuerDID string) ([]Policy, error) {
+	return s.store.GetPoliciesByIssuer(ctx, issuerDID)
+}
+
+// DeletePolicy removes a replication policy.
+func (s *Service) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeletePolicy(ctx, id)
+}
+
+// SaveTarget creates or replaces a replication target.
+func (s *Service) SaveTarget(ctx context.Context, target *Target) error {
+	if target.ID == uuid.Nil {
+		target.ID = uuid.New()
+	}
+	if target.CreatedAt.IsZero() {
+		target.CreatedAt = time.Now()
+	}
+	return s.store.SaveTarget(ctx, target)
+}
+
+// Targets lists the replication targets matching ids.
+func (s *Service) Targets(ctx context.Context, ids []uuid.UUID) ([]Target, error) {
+	return s.store.GetTargets(ctx, ids)
+}
+
+// DeleteTarget removes a replication target.
+func (s *Service) DeleteTarget(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeleteTarget(ctx, id)
+}
+
+// Log returns the replication log entries matching filter.
+func (s *Service) Log(ctx context.Context, filter LogFilter) ([]LogEntry, error) {
+	return s.store.GetLog(ctx, filter)
+}
+
+// Trigger enqueues a replication job for every policy bound to issuerDID
+// that fires on trigger, so the caller (e.g. CreateClaim after Save
+// succeeds) never blocks on the remote targets.
+func (s *Service) Trigger(ctx context.Context, issuerDID string, trigger Trigger, claimID, credentialType string, vc, credentialStatus json.RawMessage) {
+	policies, err := s.store.GetPoliciesByIssuer(ctx, issuerDID)
+	if err != nil {
+		log.Error(ctx, "can not load replication policies", err)
+		return
+	}
+
+	for _, policy := range policies {
+		if !policy.FiresOn(trigger) || !policy.Allows(credentialType) {
+			continue
+		}
+
+		_, err := s.jobsService.Enqueue(ctx, domain.JobTypeReplicate, EventParms{
+			PolicyID:         policy.ID,
+			Trigger:          trigger,
+			ClaimID:          claimID,
+			CredentialType:   credentialType,
+			VC:               vc,
+			CredentialStatus: credentialStatus,
+		})
+		if err != nil {
+			log.Error(ctx, "can not enqueue replication event", err)
+		}
+	}
+}
+
+// TriggerOnRevoke enqueues replication for every policy bound to issuerDID
+// that fires on revoke. Unlike TriggerOnIssue there is no fresh credential
+// payload to carry here, only the identifier of what was revoked, so
+// policies with a credential-type filter only match it if that filter is
+// empty.
+func (s *Service) TriggerOnRevoke(ctx context.Context, issuerDID, claimID string) {
+	s.Trigger(ctx, issuerDID, TriggerOnRevoke, claimID, "", nil, nil)
+}
+
+// TriggerOnStatePublish enqueues replication for every policy bound to
+// issuerDID that fires on state publish.
+func (s *Service) TriggerOnStatePublish(ctx context.Context, issuerDID string) {
+	s.Trigger(ctx, issuerDID, TriggerOnStatePublish, "", "", nil, nil)
+}
+
+// WireJobTriggers registers a success hook on pool so that replication fires
+// once a revoke_claim or publish_state job has actually finished, rather
+// than at enqueue time. Call once at startup, after both pool and svc have
+// been constructed and before pool.Start.
+func WireJobTriggers(pool *jobs.WorkerPool, svc *Service) {
+	pool.RegisterOnSuccess(func(ctx context.Context, job *domain.Job) {
+		switch job.Type {
+		case domain.JobTypeRevokeClaim:
+			parms := jobs.RevokeClaimParms{}
+			if err := json.Unmarshal(job.Parms, &parms); err != nil {
+				log.Error(ctx, "can not unmarshal revoke claim job parms", err)
+				return
+			}
+			svc.TriggerOnRevoke(ctx, parms.Identifier, fmt.Sprintf("%s:%d", parms.Identifier, parms.Nonce))
+		case domain.JobTypePublishState:
+			parms := jobs.PublishStateParms{}
+			if err := json.Unmarshal(job.Parms, &parms); err != nil {
+				log.Error(ctx, "can not unmarshal publish state job parms", err)
+				return
+			}
+			svc.TriggerOnStatePublish(ctx, parms.Identifier)
+		}
+	})
+}
+
+// RunScheduler polls for policies that fire on TriggerScheduled and enqueues
+// a replication job for any whose interval has elapsed since its last run.
+// Schedule is parsed as a Go duration (e.g. "1h", "15m"); policies with an
+// unparsable schedule are skipped with a logged warning. Call once at
+// startup; call the returned stop func to shut it down.
+func (s *Service) RunScheduler(ctx context.Context, pollEvery time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(pollEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDueScheduledPolicies(ctx)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// runDueScheduledPolicies enqueues a replication job for every
+// TriggerScheduled policy whose interval has elapsed. A scheduled run has no
+// single claim to carry, unlike the event-driven triggers; it fires as a
+// heartbeat sync so targets hear from the issuer between real issuance and
+// revocation events. Such a run carries no credential type, so a policy
+// with a credential-type filter posts nothing to its targets.
+func (s *Service) runDueScheduledPolicies(ctx context.Context) {
+	policies, err := s.store.GetScheduledPolicies(ctx)
+	if err != nil {
+		log.Error(ctx, "can not load scheduled replication policies", err)
+		return
+	}
+
+	now := time.Now()
+	for _, policy := range policies {
+		interval, err := time.ParseDuration(policy.Schedule)
+		if err != nil {
+			log.Warn(ctx, "replication policy has an unparsable schedule, skipping", "policy", policy.ID, "schedule", policy.Schedule)
+			continue
+		}
+		if policy.LastRunAt != nil && now.Sub(*policy.LastRunAt) < interval {
+			continue
+		}
+
+		if _, err := s.jobsService.Enqueue(ctx, domain.JobTypeReplicate, EventParms{
+			PolicyID: policy.ID,
+			Trigger:  TriggerScheduled,
+		}); err != nil {
+			log.Error(ctx, "can not enqueue scheduled replication event", err)
+			continue
+		}
+		if err := s.store.MarkPolicyRun(ctx, policy.ID, now); err != nil {
+			log.Error(ctx, "can not mark replication policy as run", err)
+		}
+	}
+}
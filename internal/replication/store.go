@@ -0,0 +1,36 @@
+package replication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogFilter narrows down a replication log listing.
+type LogFilter struct {
+	PolicyID *uuid.UUID
+}
+
+// Store is the DB-backed persistence layer for policies, targets and the
+// replication log.
+type Store interface {
+	SavePolicy(ctx context.Context, policy *Policy) error
+	GetPolicy(ctx context.Context, id uuid.UUID) (*Policy, error)
+	GetPoliciesByIssuer(ctx context.Context, issuerDID string) ([]Policy, error)
+	DeletePolicy(ctx context.Context, id uuid.UUID) error
+
+	// GetScheduledPolicies returns every policy that fires on TriggerScheduled,
+	// across all issuers, so the scheduler can check each one's due time.
+	GetScheduledPolicies(ctx context.Context) ([]Policy, error)
+	// MarkPolicyRun records that a scheduled policy just fired.
+	MarkPolicyRun(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	SaveTarget(ctx context.Context, target *Target) error
+	GetTarget(ctx context.Context, id uuid.UUID) (*Target, error)
+	GetTargets(ctx context.Context, ids []uuid.UUID) ([]Target, error)
+	DeleteTarget(ctx context.Context, id uuid.UUID) error
+
+	SaveLogEntry(ctx context.Context, entry *LogEntry) error
+	GetLog(ctx context.Context, filter LogFilter) ([]LogEntry, error)
+}
@@ -0,0 +1,109 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/log"
+)
+
+// EventParms are the job parameters stored for a replication event.
+type EventParms struct {
+	PolicyID         uuid.UUID `json:"policyId"`
+	Trigger          Trigger   `json:"trigger"`
+	ClaimID          string    `json:"claimId"`
+	CredentialType   string    `json:"credentialType"`
+	VC               json.RawMessage `json:"vc"`
+	CredentialStatus json.RawMessage `json:"credentialStatus"`
+}
+
+type targetPayload struct {
+	VC               json.RawMessage `json:"verifiableCredential"`
+	CredentialStatus json.RawMessage `json:"credentialStatus"`
+}
+
+// Handler builds the jobs.Handler that replicates one event to every
+// target bound to its policy, recording a replication_log row per target.
+func Handler(store Store, httpClient *http.Client) func(ctx context.Context, parms json.RawMessage) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return func(ctx context.Context, raw json.RawMessage) error {
+		parms := EventParms{}
+		if err := json.Unmarshal(raw, &parms); err != nil {
+			return fmt.Errorf("can not unmarshal replication event: %w", err)
+		}
+
+		policy, err := store.GetPolicy(ctx, parms.PolicyID)
+		if err != nil {
+			return fmt.Errorf("can not load replication policy: %w", err)
+		}
+
+		if !policy.Allows(parms.CredentialType) {
+			return nil
+		}
+
+		targets, err := store.GetTargets(ctx, policy.TargetIDs)
+		if err != nil {
+			return fmt.Errorf("can not load replication targets: %w", err)
+		}
+
+		payload, err := json.Marshal(targetPayload{VC: parms.VC, CredentialStatus: parms.CredentialStatus})
+		if err != nil {
+			return fmt.Errorf("can not marshal replication payload: %w", err)
+		}
+
+		var lastErr error
+		for _, target := range targets {
+			replicateErr := postToTarget(ctx, httpClient, target, payload)
+			entry := &LogEntry{
+				ID:        uuid.New(),
+				PolicyID:  policy.ID,
+				TargetID:  target.ID,
+				ClaimID:   parms.ClaimID,
+				Trigger:   parms.Trigger,
+				Success:   replicateErr == nil,
+				CreatedAt: time.Now(),
+			}
+			if replicateErr != nil {
+				entry.Error = replicateErr.Error()
+				lastErr = replicateErr
+				log.Error(ctx, "can not replicate claim to target", replicateErr)
+			}
+			if err := store.SaveLogEntry(ctx, entry); err != nil {
+				log.Error(ctx, "can not save replication log entry", err)
+			}
+		}
+
+		return lastErr
+	}
+}
+
+func postToTarget(ctx context.Context, client *http.Client, target Target, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if target.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+target.AuthToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("target %s responded with status %d", target.Name, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package repositories
+
+import "github.com/polygonid/sh-id-platform/internal/errs"
+
+// ErrClaimDoesNotExist is returned when a claim cannot be found by its
+// identifier/nonce. It carries its own HTTP status (404) so handlers can
+// surface it via render.Error without a type switch.
+var ErrClaimDoesNotExist = errs.NotFound("the claim does not exist")
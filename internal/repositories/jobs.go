@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/db"
+	"github.com/polygonid/sh-id-platform/internal/errs"
+)
+
+// ErrJobDoesNotExist is returned when a job cannot be found by id. It
+// carries its own HTTP status (404) so handlers can surface it via
+// render.Error without a type switch.
+var ErrJobDoesNotExist = errs.NotFound("job does not exist")
+
+// jobs is the postgres-backed implementation of ports.JobsRepository.
+type jobs struct{}
+
+// NewJobs is a jobs repository constructor.
+func NewJobs() ports.JobsRepository {
+	return &jobs{}
+}
+
+func (j *jobs) Save(ctx context.Context, job *domain.Job) error {
+	conn := db.FromContext(ctx)
+	_, err := conn.Exec(ctx,
+		`INSERT INTO jobs (id, job_type, status, parms, error, attempts, next_attempt_at, creation_time, update_time)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (id) DO UPDATE SET
+		   status = $3, parms = $4, error = $5, attempts = $6, next_attempt_at = $7, update_time = $9`,
+		job.ID, job.Type, job.Status, job.Parms, job.Error, job.Attempts, job.NextAttemptAt, job.CreationTime, job.UpdateTime)
+	return err
+}
+
+func (j *jobs) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	conn := db.FromContext(ctx)
+	row := conn.QueryRow(ctx,
+		`SELECT id, job_type, status, parms, error, attempts, next_attempt_at, creation_time, update_time
+		 FROM jobs WHERE id = $1`, id)
+
+	job := domain.Job{}
+	if err := row.Scan(&job.ID, &job.Type, &job.Status, &job.Parms, &job.Error, &job.Attempts, &job.NextAttemptAt, &job.CreationTime, &job.UpdateTime); err != nil {
+		if db.IsNoRows(err) {
+			return nil, ErrJobDoesNotExist
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (j *jobs) GetAll(ctx context.Context, filter ports.JobsFilter) ([]domain.Job, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT id, job_type, status, parms, error, attempts, next_attempt_at, creation_time, update_time
+		 FROM jobs
+		 WHERE ($1::text IS NULL OR job_type = $1)
+		   AND ($2::text IS NULL OR status = $2)
+		 ORDER BY creation_time DESC`,
+		filter.Type, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobList := make([]domain.Job, 0)
+	for rows.Next() {
+		job := domain.Job{}
+		if err := rows.Scan(&job.ID, &job.Type, &job.Status, &job.Parms, &job.Error, &job.Attempts, &job.NextAttemptAt, &job.CreationTime, &job.UpdateTime); err != nil {
+			return nil, err
+		}
+		jobList = append(jobList, job)
+	}
+	return jobList, rows.Err()
+}
+
+// ClaimPending atomically marks up to limit due pending/retrying jobs as
+// running in a single UPDATE ... RETURNING, using SKIP LOCKED so that two
+// callers racing this query never claim the same row. A job only becomes
+// eligible once next_attempt_at has passed, which is what actually makes
+// backoff between retries take effect.
+func (j *jobs) ClaimPending(ctx context.Context, limit int) ([]domain.Job, error) {
+	conn := db.FromContext(ctx)
+	rows, err := conn.Query(ctx,
+		`UPDATE jobs SET status = 'running', update_time = now()
+		 WHERE id IN (
+		   SELECT id FROM jobs
+		   WHERE status IN ('pending', 'retrying') AND next_attempt_at <= now()
+		   ORDER BY creation_time ASC
+		   LIMIT $1
+		   FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, job_type, status, parms, error, attempts, next_attempt_at, creation_time, update_time`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobList := make([]domain.Job, 0)
+	for rows.Next() {
+		job := domain.Job{}
+		if err := rows.Scan(&job.ID, &job.Type, &job.Status, &job.Parms, &job.Error, &job.Attempts, &job.NextAttemptAt, &job.CreationTime, &job.UpdateTime); err != nil {
+			return nil, err
+		}
+		jobList = append(jobList, job)
+	}
+	return jobList, rows.Err()
+}